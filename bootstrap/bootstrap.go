@@ -0,0 +1,205 @@
+package bootstrap
+
+import (
+	"context"
+	"crypto/rand"
+	"errors"
+	"sync"
+	"time"
+
+	"github.com/BochkovDev/kademlia-go/lookup"
+	"github.com/BochkovDev/kademlia-go/node"
+	"github.com/BochkovDev/kademlia-go/routing"
+)
+
+// Bootstrap joins a local node into an existing Kademlia network and keeps
+// its routing table warm over time, analogous to the Haskell
+// Network.Kademlia.Bootstrap module.
+//
+// Joining proceeds in three steps: the configured seed nodes are added to the
+// routing table, a self-lookup populates it with the nodes actually closest
+// to the local ID, and then every bucket that is not yet full is refreshed
+// with a lookup for a random ID in its range, the standard Kademlia
+// bucket-refresh trick. Bootstrap tracks when each bucket was last refreshed
+// so that Refresh can later re-run the trick only for buckets that have gone
+// stale.
+type Bootstrap struct {
+	local node.ID
+	rt    *routing.RoutingTable
+	rpc   lookup.RPC
+	alpha int
+	k     int
+
+	mu            sync.Mutex
+	lastRefreshed map[int]time.Time
+}
+
+// New creates a Bootstrap for the given local NodeID, routing table, and RPC
+// transport.
+//
+// Parameters:
+//   - local node.ID: The NodeID of the owner of rt.
+//   - rt *routing.RoutingTable: The routing table to seed and keep refreshed.
+//   - rpc lookup.RPC: Issues the FIND_NODE RPCs driving every lookup.
+//   - alpha int: The lookup concurrency; lookup.DefaultAlpha is used if <= 0.
+//   - k int: The lookup's target result size; lookup.DefaultK is used if <= 0.
+//
+// Returns:
+//   - *Bootstrap: A pointer to a newly created Bootstrap.
+func New(local node.ID, rt *routing.RoutingTable, rpc lookup.RPC, alpha, k int) *Bootstrap {
+	if alpha <= 0 {
+		alpha = lookup.DefaultAlpha
+	}
+	if k <= 0 {
+		k = lookup.DefaultK
+	}
+
+	return &Bootstrap{
+		local:         local,
+		rt:            rt,
+		rpc:           rpc,
+		alpha:         alpha,
+		k:             k,
+		lastRefreshed: make(map[int]time.Time),
+	}
+}
+
+// DefaultRefreshInterval is the bucket staleness threshold Refresh applies
+// when callers have no specific value in mind, matching the Kademlia
+// paper's one-hour refresh recommendation.
+const DefaultRefreshInterval = time.Hour
+
+// Bootstrap is a convenience wrapper around Join for callers holding
+// concrete *node.Node seeds (e.g. from a static peer list) rather than
+// node.INode values already collected from elsewhere.
+//
+// Parameters:
+//   - ctx context.Context: Governs cancellation of the self-lookup and any refresh it triggers.
+//   - seeds []*node.Node: The bootstrap peers to seed the routing table with.
+//
+// Returns:
+//   - error: Non-nil if the self-lookup or any refresh lookup failed.
+func (b *Bootstrap) Bootstrap(ctx context.Context, seeds []*node.Node) error {
+	asNodes := make([]node.INode, len(seeds))
+	for i, seed := range seeds {
+		asNodes[i] = seed
+	}
+	return b.Join(ctx, asNodes)
+}
+
+// Join seeds the routing table with seeds, performs a self-lookup so the
+// table fills in with the nodes actually closest to the local ID, then
+// refreshes every bucket that is not yet full.
+//
+// Parameters:
+//   - ctx context.Context: Governs cancellation of every lookup performed.
+//   - seeds []node.INode: The bootstrap peers to seed the routing table with.
+//
+// Returns:
+//   - error: Non-nil if the self-lookup or any refresh lookup failed.
+func (b *Bootstrap) Join(ctx context.Context, seeds []node.INode) error {
+	for _, seed := range seeds {
+		b.rt.Add(seed)
+	}
+
+	if _, err := lookup.FindNode(ctx, b.local, b.alpha, b.k, b.rt, b.rpc); err != nil {
+		return err
+	}
+
+	return b.refreshStaleBuckets(ctx, 0)
+}
+
+// Refresh periodically re-runs the bucket-refresh trick for every bucket
+// that is not full and has not been refreshed within interval, until ctx is
+// done. Full buckets are not refreshed.
+// Callers with no specific staleness threshold in mind can pass
+// DefaultRefreshInterval.
+//
+// Parameters:
+//   - ctx context.Context: Governs the loop's lifetime.
+//   - interval time.Duration: How stale a bucket must be before it is refreshed again.
+//
+// Returns:
+//   - error: ctx.Err() once ctx is done.
+func (b *Bootstrap) Refresh(ctx context.Context, interval time.Duration) error {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-ticker.C:
+			if err := b.refreshStaleBuckets(ctx, interval); err != nil {
+				return err
+			}
+		}
+	}
+}
+
+// refreshStaleBuckets runs a refresh lookup for every bucket that is not
+// full and has not been refreshed within maxAge, recording the refresh time
+// for each bucket it touches.
+func (b *Bootstrap) refreshStaleBuckets(ctx context.Context, maxAge time.Duration) error {
+	now := time.Now()
+	var errs []error
+
+	b.rt.ForEachBucket(func(prefixLen int, bucket *routing.KBucket) bool {
+		if bucket.IsFull() {
+			return true
+		}
+
+		b.mu.Lock()
+		last, seen := b.lastRefreshed[prefixLen]
+		b.mu.Unlock()
+		if seen && now.Sub(last) < maxAge {
+			return true
+		}
+
+		target, err := randomIDWithPrefix(b.local, prefixLen)
+		if err != nil {
+			errs = append(errs, err)
+			return true
+		}
+
+		if _, err := lookup.FindNode(ctx, target, b.alpha, b.k, b.rt, b.rpc); err != nil {
+			errs = append(errs, err)
+		}
+
+		b.mu.Lock()
+		b.lastRefreshed[prefixLen] = time.Now()
+		b.mu.Unlock()
+		return true
+	})
+
+	return errors.Join(errs...)
+}
+
+// randomIDWithPrefix returns a random ID, of the same concrete width as
+// local, whose common prefix length with local is exactly prefixLen,
+// landing it inside the bucket range that prefixLen identifies.
+func randomIDWithPrefix(local node.ID, prefixLen int) (node.ID, error) {
+	localBytes := local.Bytes()
+	raw := make([]byte, len(localBytes))
+	if _, err := rand.Read(raw); err != nil {
+		return nil, err
+	}
+
+	fullBytes := prefixLen / 8
+	copy(raw[:fullBytes], localBytes[:fullBytes])
+
+	if fullBytes < len(raw) {
+		bitInByte := uint(prefixLen % 8)
+		keepMask := byte(0xFF << (8 - bitInByte)) // bits shared with local
+		flipBit := byte(0x80 >> bitInByte)        // the bit that must differ from local
+		randMask := ^(keepMask | flipBit)         // bits left free to vary
+
+		b := (localBytes[fullBytes] & keepMask) | (raw[fullBytes] & randMask)
+		if localBytes[fullBytes]&flipBit == 0 {
+			b |= flipBit
+		}
+		raw[fullBytes] = b
+	}
+
+	return node.NewIDFromBytes(raw)
+}
@@ -0,0 +1,74 @@
+package bootstrap_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/BochkovDev/kademlia-go/bootstrap"
+	"github.com/BochkovDev/kademlia-go/node"
+	"github.com/BochkovDev/kademlia-go/routing"
+)
+
+// stubRPC answers every FindNode call with a fixed, empty result, which is
+// enough to exercise Join's and Refresh's control flow without a real
+// network.
+type stubRPC struct{}
+
+func (stubRPC) FindNode(ctx context.Context, peer node.INode, target node.ID) ([]node.INode, error) {
+	return nil, nil
+}
+
+// TestJoinSeedsRoutingTable checks that Join adds every seed to the routing
+// table.
+func TestJoinSeedsRoutingTable(t *testing.T) {
+	local := node.NewNode([]byte("local"), nil, 1)
+	seed := node.NewNode([]byte("seed"), nil, 1)
+
+	rt := routing.NewRoutingTable(local.ID(), 20)
+	b := bootstrap.New(local.ID(), rt, stubRPC{}, 3, 20)
+
+	if err := b.Join(context.Background(), []node.INode{seed}); err != nil {
+		t.Fatalf("Join failed: %v", err)
+	}
+
+	closest := rt.Closest(seed.ID(), 1)
+	if len(closest) != 1 || !closest[0].ID().Equals(seed.ID()) {
+		t.Errorf("Join failed, expected seed %s to be in the routing table", seed.ID())
+	}
+}
+
+// TestBootstrapSeedsRoutingTable checks that Bootstrap, the *node.Node
+// convenience wrapper around Join, adds every seed to the routing table.
+func TestBootstrapSeedsRoutingTable(t *testing.T) {
+	local := node.NewNode([]byte("local"), nil, 1)
+	seed := node.NewNode([]byte("seed"), nil, 1)
+
+	rt := routing.NewRoutingTable(local.ID(), 20)
+	b := bootstrap.New(local.ID(), rt, stubRPC{}, 3, 20)
+
+	if err := b.Bootstrap(context.Background(), []*node.Node{seed}); err != nil {
+		t.Fatalf("Bootstrap failed: %v", err)
+	}
+
+	closest := rt.Closest(seed.ID(), 1)
+	if len(closest) != 1 || !closest[0].ID().Equals(seed.ID()) {
+		t.Errorf("Bootstrap failed, expected seed %s to be in the routing table", seed.ID())
+	}
+}
+
+// TestRefreshStopsOnContextCancel checks that Refresh returns once its
+// context is cancelled.
+func TestRefreshStopsOnContextCancel(t *testing.T) {
+	local := node.NewNode([]byte("local"), nil, 1)
+	rt := routing.NewRoutingTable(local.ID(), 20)
+	b := bootstrap.New(local.ID(), rt, stubRPC{}, 3, 20)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+
+	err := b.Refresh(ctx, 5*time.Millisecond)
+	if err == nil {
+		t.Error("Refresh failed, expected an error once the context was done")
+	}
+}
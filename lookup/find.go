@@ -0,0 +1,90 @@
+package lookup
+
+import (
+	"context"
+
+	"github.com/BochkovDev/kademlia-go/node"
+	"github.com/BochkovDev/kademlia-go/routing"
+)
+
+// DefaultAlpha is the default number of FIND_NODE RPCs kept outstanding in
+// parallel during an iterative lookup, matching the α parameter from the
+// Kademlia paper.
+const DefaultAlpha = 3
+
+// DefaultK is the default number of closest nodes an iterative lookup tries
+// to resolve, matching the k-bucket size parameter.
+const DefaultK = 20
+
+// findResponse carries the outcome of a single FIND_NODE RPC back to
+// FindNode's dispatch loop.
+type findResponse struct {
+	peer  node.INode
+	nodes []node.INode
+	err   error
+}
+
+// FindNode performs the classic Kademlia iterative node lookup for target.
+//
+// It seeds a shortlist from rt's closest known nodes to target, then keeps up
+// to alpha FIND_NODE RPCs outstanding against the closest unqueried nodes in
+// the shortlist: as soon as any single RPC returns, its result is merged into
+// the shortlist and a replacement RPC is dispatched immediately, rather than
+// waiting for the rest of its batch. The lookup terminates once the k closest
+// nodes it has heard of have all either responded or been declared dead, or
+// once ctx is done.
+//
+// Parameters:
+//   - ctx context.Context: Governs cancellation of the whole lookup.
+//   - target node.ID: The NodeID being searched for.
+//   - alpha int: The number of RPCs kept outstanding in parallel; DefaultAlpha is used if <= 0.
+//   - k int: The number of closest nodes to resolve; DefaultK is used if <= 0.
+//   - rt *routing.RoutingTable: Supplies the initial shortlist.
+//   - rpc RPC: Issues the FIND_NODE RPCs.
+//
+// Returns:
+//   - []node.INode: Up to k nodes, ordered by ascending XOR distance to target.
+//   - error: Non-nil if ctx was done before the lookup converged.
+func FindNode(ctx context.Context, target node.ID, alpha, k int, rt *routing.RoutingTable, rpc RPC) ([]node.INode, error) {
+	if alpha <= 0 {
+		alpha = DefaultAlpha
+	}
+	if k <= 0 {
+		k = DefaultK
+	}
+
+	list := newShortlist(target, k)
+	list.merge(rt.Closest(target, k))
+
+	results := make(chan findResponse)
+	dispatch := func(peers []node.INode) int {
+		for _, peer := range peers {
+			go func(peer node.INode) {
+				nodes, err := rpc.FindNode(ctx, peer, target)
+				select {
+				case results <- findResponse{peer: peer, nodes: nodes, err: err}:
+				case <-ctx.Done():
+				}
+			}(peer)
+		}
+		return len(peers)
+	}
+
+	outstanding := dispatch(list.pending(alpha))
+	for outstanding > 0 {
+		select {
+		case <-ctx.Done():
+			return list.closest(k), ctx.Err()
+		case r := <-results:
+			outstanding--
+			if r.err != nil {
+				list.markFailed(r.peer)
+			} else {
+				list.merge(r.nodes)
+			}
+			outstanding += dispatch(list.pending(alpha - outstanding))
+		}
+	}
+
+	return list.closest(k), nil
+}
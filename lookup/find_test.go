@@ -0,0 +1,105 @@
+package lookup_test
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+
+	"github.com/BochkovDev/kademlia-go/lookup"
+	"github.com/BochkovDev/kademlia-go/node"
+	"github.com/BochkovDev/kademlia-go/routing"
+)
+
+// fakeRPC answers FindNode from a fixed adjacency map, simulating a small
+// fully-known network for testing the iterative lookup in isolation.
+type fakeRPC struct {
+	mu        sync.Mutex
+	neighbors map[node.ID][]node.INode
+	queried   []node.ID
+}
+
+func newFakeRPC() *fakeRPC {
+	return &fakeRPC{neighbors: make(map[node.ID][]node.INode)}
+}
+
+func (r *fakeRPC) link(from *node.Node, to ...node.INode) {
+	r.neighbors[from.ID()] = append(r.neighbors[from.ID()], to...)
+}
+
+func (r *fakeRPC) FindNode(ctx context.Context, peer node.INode, target node.ID) ([]node.INode, error) {
+	r.mu.Lock()
+	r.queried = append(r.queried, peer.ID())
+	neighbors, ok := r.neighbors[peer.ID()]
+	r.mu.Unlock()
+
+	if !ok {
+		return nil, errors.New("fakeRPC: unknown peer")
+	}
+	return neighbors, nil
+}
+
+// TestFindNodeDiscoversTransitiveNeighbor checks that the iterative lookup
+// follows FIND_NODE responses to reach a node the routing table did not
+// initially know about.
+func TestFindNodeDiscoversTransitiveNeighbor(t *testing.T) {
+	local := node.NewNode([]byte("local"), nil, 1)
+	seed := node.NewNode([]byte("seed"), nil, 1)
+	target := node.NewNode([]byte("target"), nil, 1)
+
+	rpc := newFakeRPC()
+	rpc.link(seed, target)
+	rpc.link(target)
+
+	rt := routing.NewRoutingTable(local.ID(), 20)
+	rt.Add(seed)
+
+	results, err := lookup.FindNode(context.Background(), target.ID(), 3, 20, rt, rpc)
+	if err != nil {
+		t.Fatalf("FindNode failed: %v", err)
+	}
+
+	found := false
+	for _, n := range results {
+		if n.ID().Equals(target.ID()) {
+			found = true
+		}
+	}
+	if !found {
+		t.Errorf("FindNode failed, expected target %s among results %v", target.ID(), results)
+	}
+}
+
+// TestFindNodeMarksFailedPeers checks that a peer whose RPC fails is not
+// queried twice and does not block the lookup from converging.
+func TestFindNodeMarksFailedPeers(t *testing.T) {
+	local := node.NewNode([]byte("local"), nil, 1)
+	dead := node.NewNode([]byte("dead"), nil, 1)
+	target := node.NewNode([]byte("target"), nil, 1)
+
+	rpc := newFakeRPC() // dead is never registered, so FindNode errors for it
+	rpc.link(target)    // target is alive and has no further neighbors to report
+
+	rt := routing.NewRoutingTable(local.ID(), 20)
+	rt.Add(dead)
+	rt.Add(target)
+
+	results, err := lookup.FindNode(context.Background(), target.ID(), 3, 20, rt, rpc)
+	if err != nil {
+		t.Fatalf("FindNode failed: %v", err)
+	}
+
+	deadQueries := 0
+	for _, id := range rpc.queried {
+		if id.Equals(dead.ID()) {
+			deadQueries++
+		}
+	}
+	if deadQueries != 1 {
+		t.Errorf("FindNode failed, expected dead peer to be queried exactly once, got %d", deadQueries)
+	}
+
+	if len(results) == 0 {
+		t.Error("FindNode failed, expected at least one node in the results")
+	}
+}
@@ -0,0 +1,19 @@
+package lookup
+
+import (
+	"context"
+
+	"github.com/BochkovDev/kademlia-go/node"
+)
+
+// RPC abstracts the network round-trip a node lookup needs, so FindNode can
+// be driven by any transport and unit-tested with a fake.
+//
+// Methods:
+//
+//   - FindNode(ctx context.Context, peer node.INode, target node.ID) ([]node.INode, error):
+//     Asks peer for the nodes it knows that are closest to target, mirroring
+//     the Kademlia FIND_NODE RPC.
+type RPC interface {
+	FindNode(ctx context.Context, peer node.INode, target node.ID) ([]node.INode, error)
+}
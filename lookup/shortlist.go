@@ -0,0 +1,119 @@
+package lookup
+
+import (
+	"bytes"
+	"sort"
+	"sync"
+
+	"github.com/BochkovDev/kademlia-go/node"
+)
+
+// shortlistEntry tracks the lookup state of a single candidate node.
+type shortlistEntry struct {
+	node    node.INode
+	queried bool
+	failed  bool
+}
+
+// shortlist holds every node the iterative lookup has heard of, ordered on
+// demand by XOR distance to target, along with each node's queried/failed
+// state.
+type shortlist struct {
+	target node.ID
+	k      int
+
+	mu      sync.Mutex
+	entries map[node.ID]*shortlistEntry
+}
+
+// newShortlist creates an empty shortlist for a lookup of target, considering
+// only the k closest known nodes at any point in time.
+func newShortlist(target node.ID, k int) *shortlist {
+	return &shortlist{
+		target:  target,
+		k:       k,
+		entries: make(map[node.ID]*shortlistEntry),
+	}
+}
+
+// merge adds any nodes not already known to the shortlist.
+func (s *shortlist) merge(nodes []node.INode) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for _, n := range nodes {
+		if _, ok := s.entries[n.ID()]; !ok {
+			s.entries[n.ID()] = &shortlistEntry{node: n}
+		}
+	}
+}
+
+// closestLocked returns the shortlist's entries sorted by ascending XOR
+// distance to target. Callers must hold s.mu.
+func (s *shortlist) closestLocked() []*shortlistEntry {
+	sorted := make([]*shortlistEntry, 0, len(s.entries))
+	for _, e := range s.entries {
+		sorted = append(sorted, e)
+	}
+	sort.Slice(sorted, func(i, j int) bool {
+		di := s.target.XOR(sorted[i].node.ID())
+		dj := s.target.XOR(sorted[j].node.ID())
+		return bytes.Compare(di.Bytes(), dj.Bytes()) < 0
+	})
+	return sorted
+}
+
+// pending returns up to alpha of the k closest known nodes that have not yet
+// been queried or declared dead, marking them as queried before returning.
+func (s *shortlist) pending(alpha int) []node.INode {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	closest := s.closestLocked()
+	if len(closest) > s.k {
+		closest = closest[:s.k]
+	}
+
+	batch := make([]node.INode, 0, alpha)
+	for _, e := range closest {
+		if len(batch) >= alpha {
+			break
+		}
+		if e.queried || e.failed {
+			continue
+		}
+		e.queried = true
+		batch = append(batch, e.node)
+	}
+	return batch
+}
+
+// markFailed records that n did not respond to its FIND_NODE RPC.
+func (s *shortlist) markFailed(n node.INode) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if e, ok := s.entries[n.ID()]; ok {
+		e.failed = true
+	}
+}
+
+// closest returns up to count live nodes from the shortlist, sorted by
+// ascending XOR distance to target.
+func (s *shortlist) closest(count int) []node.INode {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	sorted := s.closestLocked()
+	result := make([]node.INode, 0, count)
+	for _, e := range sorted {
+		if e.failed {
+			continue
+		}
+		if len(result) >= count {
+			break
+		}
+		result = append(result, e.node)
+	}
+	return result
+}
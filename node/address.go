@@ -0,0 +1,205 @@
+package node
+
+import (
+	"net"
+	"sync"
+)
+
+// Proto identifies the transport protocol an Endpoint is reachable over.
+type Proto uint8
+
+const (
+	// ProtoUDP marks an Endpoint reachable over UDP, the default transport
+	// for Kademlia RPCs.
+	ProtoUDP Proto = iota
+	// ProtoTCP marks an Endpoint reachable over TCP.
+	ProtoTCP
+	// ProtoQUIC marks an Endpoint reachable over QUIC.
+	ProtoQUIC
+)
+
+// String returns a human-readable name for the protocol.
+func (p Proto) String() string {
+	switch p {
+	case ProtoUDP:
+		return "udp"
+	case ProtoTCP:
+		return "tcp"
+	case ProtoQUIC:
+		return "quic"
+	default:
+		return "unknown"
+	}
+}
+
+// Endpoint is a single network address a peer can be reached at.
+//
+// A peer reachable on several interfaces (LAN, WAN, IPv6, ...) or over
+// several transports is represented by several Endpoints held in the same
+// Node's AddressBook, rather than by a single IP/port pair.
+//
+// Fields:
+//
+//   - IP net.IP:
+//     The IP address of this endpoint. It can be either an IPv4 or IPv6 address.
+//
+//   - Port uint16:
+//     The port used for data traffic (RPC requests/responses) at this endpoint.
+//
+//   - DiscoveryPort uint16:
+//     The port used for discovery traffic at this endpoint, for deployments
+//     where discovery (e.g. bootstrapping, FIND_NODE) and data traffic are
+//     split across different ports. Zero means discovery shares Port.
+//
+//   - Proto Proto:
+//     The transport protocol this endpoint is reachable over.
+type Endpoint struct {
+	IP            net.IP
+	Port          uint16
+	DiscoveryPort uint16
+	Proto         Proto
+}
+
+// Equals compares two Endpoints for equality by IP, Port, and Proto.
+// DiscoveryPort is not part of an endpoint's identity: it is auxiliary
+// metadata about an otherwise identical address.
+func (e Endpoint) Equals(other Endpoint) bool {
+	return e.IP.Equal(other.IP) && e.Port == other.Port && e.Proto == other.Proto
+}
+
+// maxEndpointFailures is the number of consecutive failed dial attempts an
+// endpoint may accumulate before AddressBook prunes it outright.
+const maxEndpointFailures = 3
+
+// addressEntry pairs an Endpoint with the consecutive-failure counter used
+// to demote and eventually prune it.
+type addressEntry struct {
+	endpoint Endpoint
+	failures int
+}
+
+// AddressBook holds the set of Endpoints a single peer is reachable at.
+//
+// Endpoints are kept ordered by preference: First returns the entry at the
+// front of the list, and a failing endpoint is demoted toward the back
+// rather than removed immediately, so that a transient failure does not
+// discard an otherwise-good address. An endpoint that keeps failing past
+// maxEndpointFailures is pruned.
+//
+// References:
+//   - libp2p peerstore's AddrBook, which this type mirrors the shape of.
+type AddressBook struct {
+	mu      sync.Mutex
+	entries []*addressEntry
+}
+
+// NewAddressBook creates and returns a new, empty AddressBook.
+func NewAddressBook() *AddressBook {
+	return &AddressBook{}
+}
+
+// Add inserts ep into the AddressBook, or resets its failure counter if it
+// is already present.
+//
+// Parameters:
+//   - ep Endpoint: The endpoint to add.
+func (ab *AddressBook) Add(ep Endpoint) {
+	ab.mu.Lock()
+	defer ab.mu.Unlock()
+
+	for _, e := range ab.entries {
+		if e.endpoint.Equals(ep) {
+			e.failures = 0
+			return
+		}
+	}
+
+	ab.entries = append(ab.entries, &addressEntry{endpoint: ep})
+}
+
+// Remove deletes ep from the AddressBook, if present.
+//
+// Parameters:
+//   - ep Endpoint: The endpoint to remove.
+func (ab *AddressBook) Remove(ep Endpoint) {
+	ab.mu.Lock()
+	defer ab.mu.Unlock()
+
+	for i, e := range ab.entries {
+		if e.endpoint.Equals(ep) {
+			ab.entries = append(ab.entries[:i], ab.entries[i+1:]...)
+			return
+		}
+	}
+}
+
+// First returns the most-preferred endpoint, i.e. the one at the front of
+// the AddressBook's internal order. It returns the zero Endpoint if the
+// AddressBook is empty.
+func (ab *AddressBook) First() Endpoint {
+	ab.mu.Lock()
+	defer ab.mu.Unlock()
+
+	if len(ab.entries) == 0 {
+		return Endpoint{}
+	}
+	return ab.entries[0].endpoint
+}
+
+// All returns every endpoint currently held, in preference order.
+func (ab *AddressBook) All() []Endpoint {
+	ab.mu.Lock()
+	defer ab.mu.Unlock()
+
+	result := make([]Endpoint, len(ab.entries))
+	for i, e := range ab.entries {
+		result[i] = e.endpoint
+	}
+	return result
+}
+
+// MarkFailed records a failed dial attempt against ep. The endpoint is
+// demoted to the back of the AddressBook so that healthier endpoints are
+// preferred by First, and it is pruned entirely once it has accumulated
+// maxEndpointFailures consecutive failures.
+//
+// Parameters:
+//   - ep Endpoint: The endpoint that failed to respond.
+func (ab *AddressBook) MarkFailed(ep Endpoint) {
+	ab.mu.Lock()
+	defer ab.mu.Unlock()
+
+	for i, e := range ab.entries {
+		if !e.endpoint.Equals(ep) {
+			continue
+		}
+
+		e.failures++
+		ab.entries = append(ab.entries[:i], ab.entries[i+1:]...)
+		if e.failures < maxEndpointFailures {
+			ab.entries = append(ab.entries, e)
+		}
+		return
+	}
+}
+
+// MarkSuccess resets ep's failure counter and promotes it to the front of
+// the AddressBook, making it the endpoint First returns.
+//
+// Parameters:
+//   - ep Endpoint: The endpoint that was successfully reached.
+func (ab *AddressBook) MarkSuccess(ep Endpoint) {
+	ab.mu.Lock()
+	defer ab.mu.Unlock()
+
+	for i, e := range ab.entries {
+		if !e.endpoint.Equals(ep) {
+			continue
+		}
+
+		e.failures = 0
+		ab.entries = append(ab.entries[:i], ab.entries[i+1:]...)
+		ab.entries = append([]*addressEntry{e}, ab.entries...)
+		return
+	}
+}
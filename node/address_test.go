@@ -0,0 +1,101 @@
+package node_test
+
+import (
+	"net"
+	"testing"
+
+	"github.com/BochkovDev/kademlia-go/node"
+)
+
+// TestAddressBookAddAndFirst checks that Add inserts endpoints and that
+// First returns the one at the front of the book.
+func TestAddressBookAddAndFirst(t *testing.T) {
+	ab := node.NewAddressBook()
+
+	lan := node.Endpoint{IP: net.ParseIP("192.168.1.1"), Port: 6881, Proto: node.ProtoUDP}
+	wan := node.Endpoint{IP: net.ParseIP("203.0.113.7"), Port: 6881, Proto: node.ProtoUDP}
+
+	ab.Add(lan)
+	ab.Add(wan)
+
+	if first := ab.First(); !first.Equals(lan) {
+		t.Errorf("First() failed, expected %v, got %v", lan, first)
+	}
+
+	all := ab.All()
+	if len(all) != 2 {
+		t.Fatalf("All() failed, expected 2 endpoints, got %d", len(all))
+	}
+}
+
+// TestAddressBookAddDeduplicates checks that re-adding an already-known
+// endpoint does not create a duplicate entry.
+func TestAddressBookAddDeduplicates(t *testing.T) {
+	ab := node.NewAddressBook()
+	ep := node.Endpoint{IP: net.ParseIP("192.168.1.1"), Port: 6881, Proto: node.ProtoUDP}
+
+	ab.Add(ep)
+	ab.Add(ep)
+
+	if all := ab.All(); len(all) != 1 {
+		t.Errorf("Add() failed to deduplicate, expected 1 endpoint, got %d", len(all))
+	}
+}
+
+// TestAddressBookRemove checks that Remove deletes a known endpoint.
+func TestAddressBookRemove(t *testing.T) {
+	ab := node.NewAddressBook()
+	ep := node.Endpoint{IP: net.ParseIP("192.168.1.1"), Port: 6881, Proto: node.ProtoUDP}
+
+	ab.Add(ep)
+	ab.Remove(ep)
+
+	if all := ab.All(); len(all) != 0 {
+		t.Errorf("Remove() failed, expected 0 endpoints, got %d", len(all))
+	}
+}
+
+// TestAddressBookMarkFailedDemotesThenPrunes checks that a failing endpoint
+// is pushed behind a healthy one, and is pruned once it accumulates enough
+// consecutive failures.
+func TestAddressBookMarkFailedDemotesThenPrunes(t *testing.T) {
+	ab := node.NewAddressBook()
+	bad := node.Endpoint{IP: net.ParseIP("192.168.1.1"), Port: 6881, Proto: node.ProtoUDP}
+	good := node.Endpoint{IP: net.ParseIP("203.0.113.7"), Port: 6881, Proto: node.ProtoUDP}
+
+	ab.Add(bad)
+	ab.Add(good)
+
+	ab.MarkFailed(bad)
+	if first := ab.First(); !first.Equals(good) {
+		t.Errorf("MarkFailed() failed to demote, expected First() %v, got %v", good, first)
+	}
+
+	ab.MarkFailed(bad)
+	ab.MarkFailed(bad)
+
+	all := ab.All()
+	if len(all) != 1 {
+		t.Fatalf("MarkFailed() failed to prune, expected 1 endpoint, got %d", len(all))
+	}
+	if !all[0].Equals(good) {
+		t.Errorf("MarkFailed() pruned the wrong endpoint, expected %v, got %v", good, all[0])
+	}
+}
+
+// TestAddressBookMarkSuccessPromotes checks that MarkSuccess resets an
+// endpoint's failure counter and moves it back to the front of the book.
+func TestAddressBookMarkSuccessPromotes(t *testing.T) {
+	ab := node.NewAddressBook()
+	first := node.Endpoint{IP: net.ParseIP("192.168.1.1"), Port: 6881, Proto: node.ProtoUDP}
+	second := node.Endpoint{IP: net.ParseIP("203.0.113.7"), Port: 6881, Proto: node.ProtoUDP}
+
+	ab.Add(first)
+	ab.Add(second)
+
+	ab.MarkSuccess(second)
+
+	if got := ab.First(); !got.Equals(second) {
+		t.Errorf("MarkSuccess() failed to promote, expected First() %v, got %v", second, got)
+	}
+}
@@ -0,0 +1,50 @@
+package node
+
+import (
+	"encoding/binary"
+	"io"
+)
+
+// WriteID encodes id as a length-prefixed byte string: a single length byte
+// followed by id.Bytes(). It is the wire codec persistence.Snapshot and
+// routing.Store.Save both build their node/ID records on top of, so the two
+// packages' otherwise-independent snapshot formats agree on how an ID itself
+// is framed.
+//
+// Parameters:
+//   - w io.Writer: The destination the encoded ID is written to.
+//   - id ID: The identifier to encode.
+//
+// Returns:
+//   - error: Non-nil if writing to w failed.
+func WriteID(w io.Writer, id ID) error {
+	raw := id.Bytes()
+	if err := binary.Write(w, binary.BigEndian, uint8(len(raw))); err != nil {
+		return err
+	}
+	_, err := w.Write(raw)
+	return err
+}
+
+// ReadID decodes an ID written by WriteID, reconstructing the same concrete
+// ID type (ID160 or ID256) regardless of which Hasher produced it.
+//
+// Parameters:
+//   - r io.Reader: The source an encoded ID is read from.
+//
+// Returns:
+//   - ID: The decoded identifier.
+//   - error: Non-nil if r did not contain a WriteID-encoded ID.
+func ReadID(r io.Reader) (ID, error) {
+	var idLen uint8
+	if err := binary.Read(r, binary.BigEndian, &idLen); err != nil {
+		return nil, err
+	}
+
+	raw := make([]byte, idLen)
+	if _, err := io.ReadFull(r, raw); err != nil {
+		return nil, err
+	}
+
+	return NewIDFromBytes(raw)
+}
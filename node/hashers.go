@@ -0,0 +1,39 @@
+package node
+
+import (
+	"crypto/sha1"
+	"crypto/sha256"
+
+	"golang.org/x/crypto/sha3"
+)
+
+// SHA1Hasher produces 160-bit IDs via SHA-1, the hash used by the original
+// Kademlia paper and kademlia-go's historical default.
+type SHA1Hasher struct{}
+
+// Sum hashes data into an ID160.
+func (SHA1Hasher) Sum(data []byte) ID {
+	return ID160(sha1.Sum(data))
+}
+
+// SHA256Hasher produces 256-bit IDs via SHA-256.
+type SHA256Hasher struct{}
+
+// Sum hashes data into an ID256.
+func (SHA256Hasher) Sum(data []byte) ID {
+	return ID256(sha256.Sum256(data))
+}
+
+// Keccak256Hasher produces 256-bit IDs via Keccak-256, the hash Ethereum's
+// node discovery protocol derives its own node IDs with.
+type Keccak256Hasher struct{}
+
+// Sum hashes data into an ID256.
+func (Keccak256Hasher) Sum(data []byte) ID {
+	h := sha3.NewLegacyKeccak256()
+	h.Write(data)
+
+	var id ID256
+	h.Sum(id[:0])
+	return id
+}
@@ -1,59 +1,110 @@
 package node
 
-import (
-	"crypto/sha1"
-	"encoding/hex"
-)
+import "fmt"
 
-// NodeID represents a unique identifier for a node in the Kademlia DHT network.
-// Each NodeID consists of a 160-bit (20-byte) value.
+// ID is a node identifier in the Kademlia keyspace.
 //
-// References:
-//   - Maymounkov, Petar; Mazieres, David. "Kademlia: A Peer-to-peer Information System Based on the XOR Metric" [Section 1, "Introduction"; 2.1, "XOR Metric"].
-//     Retrieved from: https://pdos.csail.mit.edu/~petar/papers/maymounkov-kademlia-lncs.pdf
-type NodeID [20]byte
-
-// NewNodeID generates a unique NodeID from a given input byte slice by applying the SHA-1 hashing algorithm.
-//
-// This function returns a 160-bit hash, which matches the required NodeID size for Kademlia's DHT.
-// SHA-1 was selected for its ability to produce uniformly distributed identifiers, a property essential
-// for maintaining balanced distribution and efficient lookup performance in Kademlia networks.
-//
-// Parameters:
-//   - data []byte: The input byte slice used to generate the NodeID. This input should contain unique
-//     information about the node, such as its IP address and port, to ensure a unique identifier.
+// Its concrete width is defined by whichever Hasher produced it (ID160 for
+// SHA1Hasher, ID256 for SHA256Hasher and Keccak256Hasher), so the keyspace
+// width is a construction-time choice rather than a constant hard-wired
+// into the routing and lookup logic, which operates entirely through this
+// interface.
 //
 // References:
-//   - Maymounkov, Petar; Mazieres, David. "Kademlia: A Peer-to-peer Information System Based on the XOR Metric" [Section 1, "Introduction"].
+//   - Maymounkov, Petar; Mazieres, David. "Kademlia: A Peer-to-peer Information System Based on the XOR Metric" [Section 2.1, "XOR Metric"].
 //     Retrieved from: https://pdos.csail.mit.edu/~petar/papers/maymounkov-kademlia-lncs.pdf
-func NewNodeID(data []byte) NodeID {
-	hash := sha1.Sum(data)
-	return hash
+type ID interface {
+	// Bytes returns the identifier's raw bytes, most significant byte first.
+	Bytes() []byte
+
+	// BitLen returns the number of bits in the identifier, e.g. 160 for
+	// ID160 or 256 for ID256.
+	BitLen() int
+
+	// XOR returns the bitwise XOR distance between this ID and other. The
+	// result's concrete type matches this ID's; XOR-ing IDs of different
+	// widths panics.
+	XOR(other ID) ID
+
+	// CommonPrefixLen returns the number of leading bits this ID shares
+	// with other. It is what the routing table's bucket index is based on,
+	// provided here so callers never need to bit-walk an XOR result
+	// themselves.
+	CommonPrefixLen(other ID) int
+
+	// Equals reports whether this ID is identical to other.
+	Equals(other ID) bool
+
+	// String returns the identifier's hexadecimal representation.
+	String() string
 }
 
-// String converts a NodeID into its hexadecimal string representation for easy human-readable display.
-func (id NodeID) String() string {
-	return hex.EncodeToString(id[:])
+// Hasher derives an ID from arbitrary input data, such as a node's public
+// key or its IP and port.
+//
+// Hashing the identity material before use (rather than, say, XOR-ing raw
+// public keys directly) is deliberate: a hash gives a much more uniformly
+// distributed keyspace than the raw material would, which is the lesson the
+// Ethereum discovery protocol's experience motivates.
+type Hasher interface {
+	// Sum hashes data into an ID of the Hasher's fixed width.
+	Sum(data []byte) ID
 }
 
-// Equals compares the current NodeID with another NodeID for equality.
-func (id NodeID) Equals(other NodeID) bool {
-	return id == other
+// NewNodeID hashes data with h into a new ID.
+//
+// Parameters:
+//   - h Hasher: The hash function defining the resulting ID's width and distribution.
+//   - data []byte: The input byte slice used to generate the ID. This input should
+//     contain unique information about the node, such as its IP address and port.
+//
+// Returns:
+//   - ID: The resulting identifier.
+func NewNodeID(h Hasher, data []byte) ID {
+	return h.Sum(data)
 }
 
-// XOR performs a bitwise XOR operation between the current NodeID and another NodeID.
+// NewIDFromBytes wraps raw, big-endian bytes into the ID implementation
+// matching their length: ID160 for 20 bytes, ID256 for 32 bytes. It is used
+// to reconstruct an ID from a byte slice produced by Bytes(), for example
+// when decoding a persisted snapshot or deriving a sibling ID for a bucket
+// refresh.
 //
-// This operation is fundamental to calculating the XOR distance between nodes, a metric used in Kademlia
-// to determine routing paths and proximity of nodes in the network. The XOR metric ensures efficient
-// lookups by enabling distance-based routing.
+// Parameters:
+//   - raw []byte: The raw identifier bytes.
 //
-// References:
-//   - Maymounkov, Petar; Mazieres, David. "Kademlia: A Peer-to-peer Information System Based on the XOR Metric" [Section 2.1, "XOR Metric"].
-//     Retrieved from: https://pdos.csail.mit.edu/~petar/papers/maymounkov-kademlia-lncs.pdf
-func (id NodeID) XOR(other NodeID) [20]byte {
-	var result NodeID
-	for i := 0; i < len(id); i++ {
-		result[i] = id[i] ^ other[i]
+// Returns:
+//   - ID: The wrapped identifier.
+//   - error: Non-nil if len(raw) does not match a known ID width.
+func NewIDFromBytes(raw []byte) (ID, error) {
+	switch len(raw) {
+	case idLen160:
+		var id ID160
+		copy(id[:], raw)
+		return id, nil
+	case idLen256:
+		var id ID256
+		copy(id[:], raw)
+		return id, nil
+	default:
+		return nil, fmt.Errorf("node: unsupported ID width %d bytes", len(raw))
+	}
+}
+
+// commonPrefixLenBytes returns the number of leading bits shared between a
+// and b, capped at 8*len(a). It assumes a and b have equal length, which
+// XOR's implementations on ID160 and ID256 guarantee by construction.
+func commonPrefixLenBytes(a, b []byte) int {
+	for i := range a {
+		x := a[i] ^ b[i]
+		if x == 0 {
+			continue
+		}
+		for bit := 0; bit < 8; bit++ {
+			if x&(0x80>>uint(bit)) != 0 {
+				return i*8 + bit
+			}
+		}
 	}
-	return result
+	return len(a) * 8
 }
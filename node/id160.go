@@ -0,0 +1,62 @@
+package node
+
+import "encoding/hex"
+
+// idLen160 is ID160's width in bytes.
+const idLen160 = 20
+
+// ID160 is a 160-bit ID, the width used by the original Kademlia paper and
+// produced by SHA1Hasher.
+type ID160 [idLen160]byte
+
+// Bytes returns id's raw bytes, most significant byte first.
+func (id ID160) Bytes() []byte {
+	return id[:]
+}
+
+// BitLen returns 160, the number of bits in an ID160.
+func (id ID160) BitLen() int {
+	return idLen160 * 8
+}
+
+// String converts id into its hexadecimal string representation for easy human-readable display.
+func (id ID160) String() string {
+	return hex.EncodeToString(id[:])
+}
+
+// Equals reports whether other is an ID160 identical to id.
+func (id ID160) Equals(other ID) bool {
+	o, ok := other.(ID160)
+	return ok && id == o
+}
+
+// XOR performs a bitwise XOR operation between id and other.
+//
+// It panics if other is not an ID160: XOR distance is only meaningful
+// between IDs of the same keyspace.
+//
+// References:
+//   - Maymounkov, Petar; Mazieres, David. "Kademlia: A Peer-to-peer Information System Based on the XOR Metric" [Section 2.1, "XOR Metric"].
+//     Retrieved from: https://pdos.csail.mit.edu/~petar/papers/maymounkov-kademlia-lncs.pdf
+func (id ID160) XOR(other ID) ID {
+	o, ok := other.(ID160)
+	if !ok {
+		panic("node: XOR between ID160 and a differently-widthed ID")
+	}
+
+	var result ID160
+	for i := range id {
+		result[i] = id[i] ^ o[i]
+	}
+	return result
+}
+
+// CommonPrefixLen returns the number of leading bits id shares with other.
+// It panics if other is not an ID160.
+func (id ID160) CommonPrefixLen(other ID) int {
+	o, ok := other.(ID160)
+	if !ok {
+		panic("node: CommonPrefixLen between ID160 and a differently-widthed ID")
+	}
+	return commonPrefixLenBytes(id[:], o[:])
+}
@@ -0,0 +1,59 @@
+package node
+
+import "encoding/hex"
+
+// idLen256 is ID256's width in bytes.
+const idLen256 = 32
+
+// ID256 is a 256-bit ID, the width produced by SHA256Hasher and
+// Keccak256Hasher for deployments that want a larger keyspace than the
+// original Kademlia paper's 160 bits.
+type ID256 [idLen256]byte
+
+// Bytes returns id's raw bytes, most significant byte first.
+func (id ID256) Bytes() []byte {
+	return id[:]
+}
+
+// BitLen returns 256, the number of bits in an ID256.
+func (id ID256) BitLen() int {
+	return idLen256 * 8
+}
+
+// String converts id into its hexadecimal string representation for easy human-readable display.
+func (id ID256) String() string {
+	return hex.EncodeToString(id[:])
+}
+
+// Equals reports whether other is an ID256 identical to id.
+func (id ID256) Equals(other ID) bool {
+	o, ok := other.(ID256)
+	return ok && id == o
+}
+
+// XOR performs a bitwise XOR operation between id and other.
+//
+// It panics if other is not an ID256: XOR distance is only meaningful
+// between IDs of the same keyspace.
+func (id ID256) XOR(other ID) ID {
+	o, ok := other.(ID256)
+	if !ok {
+		panic("node: XOR between ID256 and a differently-widthed ID")
+	}
+
+	var result ID256
+	for i := range id {
+		result[i] = id[i] ^ o[i]
+	}
+	return result
+}
+
+// CommonPrefixLen returns the number of leading bits id shares with other.
+// It panics if other is not an ID256.
+func (id ID256) CommonPrefixLen(other ID) int {
+	o, ok := other.(ID256)
+	if !ok {
+		panic("node: CommonPrefixLen between ID256 and a differently-widthed ID")
+	}
+	return commonPrefixLenBytes(id[:], o[:])
+}
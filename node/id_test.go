@@ -8,42 +8,43 @@ import (
 	"github.com/BochkovDev/kademlia-go/node"
 )
 
-// TestNewNodeID checks that NewNodeID generates the expected SHA-1 hash for a given input.
+// TestNewNodeID checks that NewNodeID with SHA1Hasher generates the
+// expected SHA-1 hash for a given input, as an ID160.
 func TestNewNodeID(t *testing.T) {
 	data := []byte("node_data")
-	expectedHash := sha1.Sum(data)
-	nodeID := node.NewNodeID(data)
+	expectedHash := node.ID160(sha1.Sum(data))
+	id := node.NewNodeID(node.SHA1Hasher{}, data)
 
-	if nodeID != expectedHash {
-		t.Errorf("NewNodeID failed, expected %x, got %x", expectedHash, nodeID)
+	if !id.Equals(expectedHash) {
+		t.Errorf("NewNodeID failed, expected %x, got %x", expectedHash, id)
 	}
 }
 
 // TestString checks that the String method returns the correct hexadecimal representation.
 func TestString(t *testing.T) {
 	data := []byte("node_data")
-	nodeID := node.NewNodeID(data)
-	expectedStr := hex.EncodeToString(nodeID[:])
+	id := node.NewNodeID(node.SHA1Hasher{}, data)
+	expectedStr := hex.EncodeToString(id.Bytes())
 
-	if nodeID.String() != expectedStr {
-		t.Errorf("String() failed, expected %s, got %s", expectedStr, nodeID.String())
+	if id.String() != expectedStr {
+		t.Errorf("String() failed, expected %s, got %s", expectedStr, id.String())
 	}
 }
 
-// TestEquals checks the Equals method by comparing identical and different NodeIDs.
+// TestEquals checks the Equals method by comparing identical and different IDs.
 func TestEquals(t *testing.T) {
 	data1 := []byte("node_data_1")
 	data2 := []byte("node_data_2")
 
-	nodeID1 := node.NewNodeID(data1)
-	sameNodeID := node.NewNodeID(data1)
-	nodeID2 := node.NewNodeID(data2)
+	id1 := node.NewNodeID(node.SHA1Hasher{}, data1)
+	sameID := node.NewNodeID(node.SHA1Hasher{}, data1)
+	id2 := node.NewNodeID(node.SHA1Hasher{}, data2)
 
-	if !nodeID1.Equals(sameNodeID) {
-		t.Error("Equals() failed, expected nodeID1 to equal sameNodeID")
+	if !id1.Equals(sameID) {
+		t.Error("Equals() failed, expected id1 to equal sameID")
 	}
-	if nodeID1.Equals(nodeID2) {
-		t.Error("Equals() failed, expected nodeID1 to not equal nodeID2")
+	if id1.Equals(id2) {
+		t.Error("Equals() failed, expected id1 to not equal id2")
 	}
 }
 
@@ -52,14 +53,31 @@ func TestXOR(t *testing.T) {
 	data1 := []byte("node_data_1")
 	data2 := []byte("node_data_2")
 
-	nodeID1 := node.NewNodeID(data1)
-	nodeID2 := node.NewNodeID(data2)
+	id1 := node.NewNodeID(node.SHA1Hasher{}, data1)
+	id2 := node.NewNodeID(node.SHA1Hasher{}, data2)
 
-	xorResult := nodeID1.XOR(nodeID2)
-	for i := 0; i < len(nodeID1); i++ {
-		expected := nodeID1[i] ^ nodeID2[i]
-		if xorResult[i] != expected {
-			t.Errorf("XOR() failed at byte %d, expected %x, got %x", i, expected, xorResult[i])
+	xorResult := id1.XOR(id2)
+	b1, b2, bx := id1.Bytes(), id2.Bytes(), xorResult.Bytes()
+	for i := range b1 {
+		expected := b1[i] ^ b2[i]
+		if bx[i] != expected {
+			t.Errorf("XOR() failed at byte %d, expected %x, got %x", i, expected, bx[i])
 		}
 	}
 }
+
+// TestCommonPrefixLen checks that CommonPrefixLen reports the correct
+// number of leading bits shared between two IDs.
+func TestCommonPrefixLen(t *testing.T) {
+	var a, b node.ID160
+	a[0] = 0b10110000
+	b[0] = 0b10100000
+
+	if got := node.ID(a).CommonPrefixLen(b); got != 3 {
+		t.Errorf("CommonPrefixLen() failed, expected 3, got %d", got)
+	}
+
+	if got := node.ID(a).CommonPrefixLen(a); got != a.BitLen() {
+		t.Errorf("CommonPrefixLen() of an ID with itself failed, expected %d, got %d", a.BitLen(), got)
+	}
+}
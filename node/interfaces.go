@@ -13,9 +13,10 @@ import (
 //
 // Methods:
 //
-//   - ID() NodeID:
+//   - ID() ID:
 //     Returns the unique identifier of the node. This ID is used for routing and
-//     distance calculations within the Kademlia network.
+//     distance calculations within the Kademlia network. Its concrete width
+//     depends on the Hasher the node was constructed with.
 //
 //   - Address() net.IP:
 //     Returns the IP address of the node. The address is used for network communication
@@ -25,7 +26,7 @@ import (
 //     Returns the port number the node listens on. The port is used in conjunction with
 //     the IP address to establish network connections.
 //
-//   - Distance(other *Node) [20]byte:
+//   - Distance(other *Node) ID:
 //     Calculates and returns the distance between the current node and another node using
 //     the XOR metric. The distance is used to determine the proximity of nodes in the
 //     keyspace, which is essential for routing and lookup operations in the Kademlia protocol.
@@ -34,8 +35,8 @@ import (
 // structures that include additional information or implement enhanced behaviors, while
 // maintaining compatibility with the Kademlia routing and lookup logic.
 type INode interface {
-	ID() NodeID
+	ID() ID
 	Address() net.IP
 	Port() uint16
-	Distance(other *Node) [20]byte
+	Distance(other *Node) ID
 }
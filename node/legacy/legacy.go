@@ -0,0 +1,54 @@
+// Package legacy preserves kademlia-go's original, hard-wired-to-SHA-1
+// NodeID type and constructor, for code migrating to the pluggable node.ID
+// / node.Hasher model introduced alongside node.ID160 and node.ID256.
+//
+// Deprecated: use node.ID (with node.ID160 and node.SHA1Hasher for the
+// equivalent 160-bit SHA-1 behavior) instead. This package is retained for
+// one deprecation cycle and will be removed afterward.
+package legacy
+
+import (
+	"crypto/sha1"
+	"encoding/hex"
+)
+
+// NodeID represents a unique identifier for a node in the Kademlia DHT network.
+// Each NodeID consists of a 160-bit (20-byte) value.
+//
+// Deprecated: use node.ID160 instead.
+//
+// References:
+//   - Maymounkov, Petar; Mazieres, David. "Kademlia: A Peer-to-peer Information System Based on the XOR Metric" [Section 1, "Introduction"; 2.1, "XOR Metric"].
+//     Retrieved from: https://pdos.csail.mit.edu/~petar/papers/maymounkov-kademlia-lncs.pdf
+type NodeID [20]byte
+
+// NewNodeID generates a unique NodeID from a given input byte slice by applying the SHA-1 hashing algorithm.
+//
+// Deprecated: use node.NewNodeID(node.SHA1Hasher{}, data) instead.
+//
+// Parameters:
+//   - data []byte: The input byte slice used to generate the NodeID. This input should contain unique
+//     information about the node, such as its IP address and port, to ensure a unique identifier.
+func NewNodeID(data []byte) NodeID {
+	hash := sha1.Sum(data)
+	return hash
+}
+
+// String converts a NodeID into its hexadecimal string representation for easy human-readable display.
+func (id NodeID) String() string {
+	return hex.EncodeToString(id[:])
+}
+
+// Equals compares the current NodeID with another NodeID for equality.
+func (id NodeID) Equals(other NodeID) bool {
+	return id == other
+}
+
+// XOR performs a bitwise XOR operation between the current NodeID and another NodeID.
+func (id NodeID) XOR(other NodeID) [20]byte {
+	var result NodeID
+	for i := 0; i < len(id); i++ {
+		result[i] = id[i] ^ other[i]
+	}
+	return result
+}
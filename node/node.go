@@ -11,59 +11,130 @@ import (
 // It also stores the last seen time of the node.
 // This struct is used to store and share information about other nodes in the Kademlia network.
 //
-// Fields:
-//
-//   - ID NodeID:
-//     Unique identifier of the node in the Kademlia network. This ID is
-//     computed based on a hash, such as the IP address and other data.
-//     It is used for sorting nodes and finding the closest nodes to the current node.
-//
-//   - Address net.IP:
-//     The IP address of the node, which can be used for establishing connections.
-//     It can be either an IPv4 or IPv6 address, depending on the network configuration.
-//
-//   - Port uint16:
-//     The port the node is listening on for incoming connections. The port must be in the range
-//     0-65535. It is used for connections over TCP or UDP for data exchange in the Kademlia network.
+// Its fields are unexported so that Node satisfies INode: a field and a
+// method cannot share a name on the same type, and INode requires ID(),
+// Address(), and Port() as methods. Use the ID, Address, Port, and
+// Endpoints accessor methods below, or NewNode/NewNodeWithHasher/
+// NewNodeFromID to construct one.
 //
 // References:
 //   - Maymounkov, Petar; Mazieres, David. "Kademlia: A Peer-to-peer Information System Based on the XOR Metric" [Section 2.2, "Node State"].
 //     Retrieved from: https://pdos.csail.mit.edu/~petar/papers/maymounkov-kademlia-lncs.pdf
 type Node struct {
-	ID      NodeID
-	Address net.IP
-	Port    uint16
+	id        ID
+	address   net.IP
+	port      uint16
+	endpoints *AddressBook
+}
+
+// ID implements INode, returning the node's unique identifier in the
+// Kademlia network. This ID is computed based on a hash, such as the IP
+// address and other data, and is used for sorting nodes and finding the
+// closest nodes to the current node.
+func (n *Node) ID() ID {
+	return n.id
+}
+
+// Address implements INode.
+//
+// Deprecated: use Endpoints instead. Mirrors the IP of Endpoints' first
+// entry and is kept for one release to ease the migration to multi-address
+// peers.
+func (n *Node) Address() net.IP {
+	if n.endpoints == nil {
+		return n.address
+	}
+	return n.endpoints.First().IP
 }
 
-// NewNode creates and returns a new Node instance with a unique NodeID,
-// based on the provided data, IP address, and port.
+// Port implements INode.
+//
+// Deprecated: use Endpoints instead. Mirrors the port of Endpoints' first
+// entry and is kept for one release to ease the migration to multi-address
+// peers.
+func (n *Node) Port() uint16 {
+	if n.endpoints == nil {
+		return n.port
+	}
+	return n.endpoints.First().Port
+}
+
+// Endpoints returns the set of network addresses this node is reachable
+// at, covering peers with several interfaces (LAN, WAN, IPv6) or
+// transports.
+func (n *Node) Endpoints() *AddressBook {
+	return n.endpoints
+}
+
+// NewNode creates and returns a new Node instance with a unique ID, hashed
+// with SHA1Hasher for backward compatibility with kademlia-go's historical
+// 160-bit keyspace, based on the provided data, IP address, and port.
+//
+// Use NewNodeWithHasher to construct a Node in a different keyspace, e.g.
+// one hashed with SHA256Hasher or Keccak256Hasher.
 //
 // Parameters:
-//   - data []byte: Byte slice input used to generate the NodeID, typically based on unique information like IP and port.
+//   - data []byte: Byte slice input used to generate the ID, typically based on unique information like IP and port.
 //   - address net.IP: The IP address of the node, which can be IPv4 or IPv6, specifying its network location.
 //   - port uint16: The port number the node listens on, used to facilitate network communication.
 //
 // Returns:
 //   - *Node: A pointer to a newly created Node, with its ID, address, and port initialized.
 func NewNode(data []byte, address net.IP, port uint16) *Node {
+	return NewNodeWithHasher(SHA1Hasher{}, data, address, port)
+}
+
+// NewNodeWithHasher creates and returns a new Node instance whose ID is
+// derived from data with h, based on the provided data, IP address, and
+// port.
+//
+// Parameters:
+//   - h Hasher: The hash function defining the resulting ID's width and distribution.
+//   - data []byte: Byte slice input used to generate the ID, typically based on unique information like IP and port.
+//   - address net.IP: The IP address of the node, which can be IPv4 or IPv6, specifying its network location.
+//   - port uint16: The port number the node listens on, used to facilitate network communication.
+//
+// Returns:
+//   - *Node: A pointer to a newly created Node, with its ID, address, and port initialized.
+func NewNodeWithHasher(h Hasher, data []byte, address net.IP, port uint16) *Node {
+	return NewNodeFromID(NewNodeID(h, data), address, port)
+}
+
+// NewNodeFromID creates and returns a new Node instance from an ID already
+// known by the caller, rather than one derived from a Hasher, for
+// reconstructing a node whose ID was determined elsewhere, e.g. when
+// decoding a persisted snapshot or a FIND_NODE response.
+//
+// Parameters:
+//   - id ID: The node's identifier.
+//   - address net.IP: The IP address of the node, which can be IPv4 or IPv6, specifying its network location.
+//   - port uint16: The port number the node listens on, used to facilitate network communication.
+//
+// Returns:
+//   - *Node: A pointer to a newly created Node, with its ID, address, and port initialized.
+func NewNodeFromID(id ID, address net.IP, port uint16) *Node {
+	endpoints := NewAddressBook()
+	endpoints.Add(Endpoint{IP: address, Port: port})
+
 	return &Node{
-		ID:      NewNodeID(data),
-		Address: address,
-		Port:    port,
+		id:        id,
+		address:   address,
+		port:      port,
+		endpoints: endpoints,
 	}
 }
 
 // Distance calculates the distance between the current node and another node in the Kademlia DHT.
 //
-// The distance is determined using the XOR metric, which is applied between the NodeIDs of
-// the current node and the other node. The result is a 160-bit value that represents the
-// proximity or distance between the nodes in the Kademlia keyspace.
+// The distance is determined using the XOR metric, which is applied between the IDs of
+// the current node and the other node. It panics if the two nodes' IDs are not the same
+// concrete type, e.g. one ID160 and the other ID256.
 //
 // The smaller the result, the closer the nodes are in the network.
 //
 // References:
 //   - Maymounkov, Petar; Mazieres, David. "Kademlia: A Peer-to-peer Information System Based on the XOR Metric" [Section 2.1, "XOR Metric"]:
 //     https://pdos.csail.mit.edu/~petar/papers/maymounkov-kademlia-lncs.pdf
-func (node *Node) Distance(other *Node) [20]byte {
-	return node.ID.XOR(other.ID)
+func (n *Node) Distance(other *Node) ID {
+	return n.id.XOR(other.id)
 }
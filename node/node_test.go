@@ -15,17 +15,17 @@ func TestNewNode(t *testing.T) {
 
 	testNode := node.NewNode(data, address, port)
 
-	expectedID := node.NewNodeID(data)
-	if testNode.ID != expectedID {
-		t.Errorf("node.NewNode failed, expected ID %x, got %x", expectedID, testNode.ID)
+	expectedID := node.NewNodeID(node.SHA1Hasher{}, data)
+	if !testNode.ID().Equals(expectedID) {
+		t.Errorf("node.NewNode failed, expected ID %x, got %x", expectedID, testNode.ID())
 	}
 
-	if !testNode.Address.Equal(address) {
-		t.Errorf("node.NewNode failed, expected Address %s, got %s", address, testNode.Address)
+	if !testNode.Address().Equal(address) {
+		t.Errorf("node.NewNode failed, expected Address %s, got %s", address, testNode.Address())
 	}
 
-	if testNode.Port != port {
-		t.Errorf("node.NewNode failed, expected Port %d, got %d", port, testNode.Port)
+	if testNode.Port() != port {
+		t.Errorf("node.NewNode failed, expected Port %d, got %d", port, testNode.Port())
 	}
 }
 
@@ -40,10 +40,10 @@ func TestDistance(t *testing.T) {
 	node1 := node.NewNode(data1, address1, port)
 	node2 := node.NewNode(data2, address2, port)
 
-	expectedDistance := node1.ID.XOR(node2.ID)
+	expectedDistance := node1.ID().XOR(node2.ID())
 	calculatedDistance := node1.Distance(node2)
 
-	if calculatedDistance != expectedDistance {
+	if !calculatedDistance.Equals(expectedDistance) {
 		t.Errorf("Distance failed, expected %x, got %x", expectedDistance, calculatedDistance)
 	}
 }
@@ -57,8 +57,8 @@ func TestSameNodeDistance(t *testing.T) {
 	testNode := node.NewNode(data, address, port)
 	distance := testNode.Distance(testNode)
 
-	var zeroDistance [20]byte
-	if distance != zeroDistance {
+	var zeroDistance node.ID160
+	if !distance.Equals(zeroDistance) {
 		t.Errorf("Distance failed, expected zero distance, got %x", distance)
 	}
 }
@@ -0,0 +1,298 @@
+// Package persistence snapshots and restores a routing.RoutingTable and a
+// store.Store to and from disk, analogous to the Haskell
+// Network.Kademlia.Persistence module.
+package persistence
+
+import (
+	"bufio"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"time"
+
+	"github.com/BochkovDev/kademlia-go/node"
+	"github.com/BochkovDev/kademlia-go/routing"
+	"github.com/BochkovDev/kademlia-go/store"
+)
+
+// magic identifies a stream as a kademlia-go persistence snapshot, so Restore
+// can reject arbitrary input before attempting to decode it.
+var magic = [4]byte{'K', 'D', 'P', 'S'}
+
+// version is the current snapshot format version, written after magic so the
+// encoding can evolve; Restore rejects any version it does not recognize.
+const version uint16 = 1
+
+// ErrBadMagic is returned by Restore when r does not begin with the
+// kademlia-go snapshot magic bytes.
+var ErrBadMagic = errors.New("persistence: not a kademlia-go snapshot")
+
+// ErrUnsupportedVersion is returned by Restore when the snapshot was written
+// by a newer, incompatible format version.
+var ErrUnsupportedVersion = errors.New("persistence: unsupported snapshot version")
+
+// Snapshot writes a versioned, length-prefixed binary encoding of rt's nodes
+// and s's records to w.
+//
+// Parameters:
+//   - rt *routing.RoutingTable: The routing table to snapshot.
+//   - s store.Store: The record store to snapshot.
+//   - w io.Writer: The destination the snapshot is written to.
+//
+// Returns:
+//   - error: Non-nil if writing to w failed.
+func Snapshot(rt *routing.RoutingTable, s store.Store, w io.Writer) error {
+	bw := bufio.NewWriter(w)
+
+	if _, err := bw.Write(magic[:]); err != nil {
+		return err
+	}
+	if err := binary.Write(bw, binary.BigEndian, version); err != nil {
+		return err
+	}
+
+	if err := writeID(bw, rt.Local()); err != nil {
+		return err
+	}
+	if err := bw.WriteByte(rt.KSize()); err != nil {
+		return err
+	}
+
+	var nodes []node.INode
+	rt.ForEachBucket(func(_ int, bucket *routing.KBucket) bool {
+		nodes = append(nodes, bucket.Nodes()...)
+		return true
+	})
+
+	if err := binary.Write(bw, binary.BigEndian, uint32(len(nodes))); err != nil {
+		return err
+	}
+	for _, n := range nodes {
+		if err := writeNode(bw, n); err != nil {
+			return err
+		}
+	}
+
+	var records []store.Record
+	s.Iterate(func(r store.Record) bool {
+		records = append(records, r)
+		return true
+	})
+
+	if err := binary.Write(bw, binary.BigEndian, uint32(len(records))); err != nil {
+		return err
+	}
+	for _, r := range records {
+		if err := writeRecord(bw, r); err != nil {
+			return err
+		}
+	}
+
+	return bw.Flush()
+}
+
+// Restore decodes a snapshot written by Snapshot, rebuilding a RoutingTable
+// and a store.Store from it.
+//
+// Nodes are re-inserted through RoutingTable.Add rather than trusted as-is,
+// so the bucket tree (and its split points) is rebuilt correctly regardless
+// of how it was shaped when the snapshot was taken. Records are restored
+// with their original Received timestamp intact, so TTL expiry continues
+// from where it left off across a restart.
+//
+// Parameters:
+//   - r io.Reader: The source a snapshot is read from.
+//
+// Returns:
+//   - *routing.RoutingTable: The restored routing table.
+//   - store.Store: The restored record store.
+//   - error: Non-nil if r did not contain a valid, supported snapshot.
+func Restore(r io.Reader) (*routing.RoutingTable, store.Store, error) {
+	br := bufio.NewReader(r)
+
+	var gotMagic [4]byte
+	if _, err := io.ReadFull(br, gotMagic[:]); err != nil {
+		return nil, nil, err
+	}
+	if gotMagic != magic {
+		return nil, nil, ErrBadMagic
+	}
+
+	var gotVersion uint16
+	if err := binary.Read(br, binary.BigEndian, &gotVersion); err != nil {
+		return nil, nil, err
+	}
+	if gotVersion != version {
+		return nil, nil, fmt.Errorf("%w: got %d, want %d", ErrUnsupportedVersion, gotVersion, version)
+	}
+
+	local, err := readID(br)
+	if err != nil {
+		return nil, nil, err
+	}
+	ksize, err := br.ReadByte()
+	if err != nil {
+		return nil, nil, err
+	}
+	rt := routing.NewRoutingTable(local, ksize)
+
+	var nodeCount uint32
+	if err := binary.Read(br, binary.BigEndian, &nodeCount); err != nil {
+		return nil, nil, err
+	}
+	for i := uint32(0); i < nodeCount; i++ {
+		n, err := readNode(br)
+		if err != nil {
+			return nil, nil, err
+		}
+		rt.Add(n)
+	}
+
+	s := store.NewMemoryStore(0, 0, 0)
+
+	var recordCount uint32
+	if err := binary.Read(br, binary.BigEndian, &recordCount); err != nil {
+		return nil, nil, err
+	}
+	for i := uint32(0); i < recordCount; i++ {
+		rec, err := readRecord(br)
+		if err != nil {
+			return nil, nil, err
+		}
+		if err := s.Put(rec); err != nil {
+			return nil, nil, err
+		}
+	}
+
+	return rt, s, nil
+}
+
+// writeNode encodes a single node as its length-prefixed ID, a
+// length-prefixed IP address (zero length for a nil address), and its port.
+func writeNode(w io.Writer, n node.INode) error {
+	if err := writeID(w, n.ID()); err != nil {
+		return err
+	}
+
+	addr := n.Address()
+	if err := binary.Write(w, binary.BigEndian, uint8(len(addr))); err != nil {
+		return err
+	}
+	if len(addr) > 0 {
+		if _, err := w.Write(addr); err != nil {
+			return err
+		}
+	}
+
+	return binary.Write(w, binary.BigEndian, n.Port())
+}
+
+// readNode decodes a single node written by writeNode into a *node.Node.
+func readNode(r io.Reader) (*node.Node, error) {
+	id, err := readID(r)
+	if err != nil {
+		return nil, err
+	}
+
+	var addrLen uint8
+	if err := binary.Read(r, binary.BigEndian, &addrLen); err != nil {
+		return nil, err
+	}
+	var addr net.IP
+	if addrLen > 0 {
+		addr = make(net.IP, addrLen)
+		if _, err := io.ReadFull(r, addr); err != nil {
+			return nil, err
+		}
+	}
+
+	var port uint16
+	if err := binary.Read(r, binary.BigEndian, &port); err != nil {
+		return nil, err
+	}
+
+	return node.NewNodeFromID(id, addr, port), nil
+}
+
+// writeID encodes id as a length-prefixed byte string, so readID can
+// reconstruct the same concrete ID type (ID160 or ID256) regardless of
+// which Hasher produced it. It delegates to node.WriteID, the shared codec
+// routing.Store's own ID framing is also built on.
+func writeID(w io.Writer, id node.ID) error {
+	return node.WriteID(w, id)
+}
+
+// readID decodes an ID written by writeID.
+func readID(r io.Reader) (node.ID, error) {
+	return node.ReadID(r)
+}
+
+// writeRecord encodes a single store.Record as its length-prefixed key,
+// length-prefixed publisher, a length-prefixed value, and the Received/TTL
+// timestamps needed to resume expiry tracking after a restart.
+func writeRecord(w io.Writer, r store.Record) error {
+	if err := writeID(w, r.Key); err != nil {
+		return err
+	}
+	if err := writeID(w, r.Publisher); err != nil {
+		return err
+	}
+
+	if err := binary.Write(w, binary.BigEndian, uint32(len(r.Value))); err != nil {
+		return err
+	}
+	if len(r.Value) > 0 {
+		if _, err := w.Write(r.Value); err != nil {
+			return err
+		}
+	}
+
+	if err := binary.Write(w, binary.BigEndian, r.Received.UnixNano()); err != nil {
+		return err
+	}
+	return binary.Write(w, binary.BigEndian, int64(r.TTL))
+}
+
+// readRecord decodes a single store.Record written by writeRecord.
+func readRecord(r io.Reader) (store.Record, error) {
+	var rec store.Record
+
+	key, err := readID(r)
+	if err != nil {
+		return store.Record{}, err
+	}
+	rec.Key = key
+
+	publisher, err := readID(r)
+	if err != nil {
+		return store.Record{}, err
+	}
+	rec.Publisher = publisher
+
+	var valueLen uint32
+	if err := binary.Read(r, binary.BigEndian, &valueLen); err != nil {
+		return store.Record{}, err
+	}
+	if valueLen > 0 {
+		rec.Value = make([]byte, valueLen)
+		if _, err := io.ReadFull(r, rec.Value); err != nil {
+			return store.Record{}, err
+		}
+	}
+
+	var receivedNano int64
+	if err := binary.Read(r, binary.BigEndian, &receivedNano); err != nil {
+		return store.Record{}, err
+	}
+	rec.Received = time.Unix(0, receivedNano).UTC()
+
+	var ttlNanos int64
+	if err := binary.Read(r, binary.BigEndian, &ttlNanos); err != nil {
+		return store.Record{}, err
+	}
+	rec.TTL = time.Duration(ttlNanos)
+
+	return rec, nil
+}
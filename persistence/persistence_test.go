@@ -0,0 +1,74 @@
+package persistence_test
+
+import (
+	"bytes"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/BochkovDev/kademlia-go/node"
+	"github.com/BochkovDev/kademlia-go/persistence"
+	"github.com/BochkovDev/kademlia-go/routing"
+	"github.com/BochkovDev/kademlia-go/store"
+)
+
+// TestSnapshotRestoreRoundTrip checks that every node added to a routing
+// table and every record put into a store survives a Snapshot/Restore round
+// trip, including the record's original Received timestamp.
+func TestSnapshotRestoreRoundTrip(t *testing.T) {
+	local := node.NewNode([]byte("local"), nil, 1)
+	peer := node.NewNode([]byte("peer"), net.ParseIP("192.168.1.1"), 4242)
+
+	rt := routing.NewRoutingTable(local.ID(), 20)
+	rt.Add(peer)
+
+	ms := store.NewMemoryStore(0, time.Minute, time.Hour)
+	received := time.Now().Add(-30 * time.Minute).UTC().Round(0)
+	record := store.Record{
+		Key:       node.NewNodeID(node.SHA1Hasher{}, []byte("key_1")),
+		Value:     []byte("value_1"),
+		Publisher: peer.ID(),
+		Received:  received,
+		TTL:       time.Hour,
+	}
+	if err := ms.Put(record); err != nil {
+		t.Fatalf("Put failed: %v", err)
+	}
+
+	var buf bytes.Buffer
+	if err := persistence.Snapshot(rt, ms, &buf); err != nil {
+		t.Fatalf("Snapshot failed: %v", err)
+	}
+
+	restoredRT, restoredStore, err := persistence.Restore(&buf)
+	if err != nil {
+		t.Fatalf("Restore failed: %v", err)
+	}
+
+	if !restoredRT.Local().Equals(local.ID()) {
+		t.Errorf("Restore failed, expected local %s, got %s", local.ID(), restoredRT.Local())
+	}
+	if got := restoredRT.Closest(peer.ID(), 1); len(got) != 1 || !got[0].ID().Equals(peer.ID()) {
+		t.Errorf("Restore failed, expected peer %s in restored routing table", peer.ID())
+	}
+
+	got, ok := restoredStore.Get(record.Key)
+	if !ok {
+		t.Fatal("Restore failed, expected record to be found")
+	}
+	if string(got.Value) != "value_1" {
+		t.Errorf("Restore failed, expected value %q, got %q", "value_1", got.Value)
+	}
+	if !got.Received.Equal(received) {
+		t.Errorf("Restore failed, expected Received %s, got %s", received, got.Received)
+	}
+}
+
+// TestRestoreRejectsBadMagic checks that Restore refuses input that does not
+// start with the snapshot magic bytes.
+func TestRestoreRejectsBadMagic(t *testing.T) {
+	_, _, err := persistence.Restore(bytes.NewReader([]byte("not a snapshot")))
+	if err != persistence.ErrBadMagic {
+		t.Errorf("Restore failed, expected ErrBadMagic, got %v", err)
+	}
+}
@@ -0,0 +1,109 @@
+package persistence
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/BochkovDev/kademlia-go/routing"
+	"github.com/BochkovDev/kademlia-go/store"
+)
+
+// FilePersister saves and loads RoutingTable/store.Store snapshots to and
+// from a single file on disk, writing atomically so a crash or power loss
+// mid-save cannot leave behind a truncated, unreadable snapshot.
+type FilePersister struct {
+	path string
+}
+
+// NewFilePersister creates a FilePersister that saves to and loads from
+// path.
+//
+// Parameters:
+//   - path string: The file snapshots are saved to and loaded from.
+//
+// Returns:
+//   - *FilePersister: A pointer to a newly created FilePersister.
+func NewFilePersister(path string) *FilePersister {
+	return &FilePersister{path: path}
+}
+
+// Save snapshots rt and s to a temp file alongside the persister's path and
+// atomically renames it into place, so a reader never observes a
+// partially-written snapshot.
+//
+// Parameters:
+//   - rt *routing.RoutingTable: The routing table to snapshot.
+//   - s store.Store: The record store to snapshot.
+//
+// Returns:
+//   - error: Non-nil if snapshotting or the atomic rename failed.
+func (p *FilePersister) Save(rt *routing.RoutingTable, s store.Store) error {
+	dir := filepath.Dir(p.path)
+	tmp, err := os.CreateTemp(dir, filepath.Base(p.path)+".tmp-*")
+	if err != nil {
+		return err
+	}
+	tmpPath := tmp.Name()
+
+	if err := Snapshot(rt, s, tmp); err != nil {
+		tmp.Close()
+		os.Remove(tmpPath)
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		os.Remove(tmpPath)
+		return err
+	}
+
+	if err := os.Rename(tmpPath, p.path); err != nil {
+		os.Remove(tmpPath)
+		return err
+	}
+	return nil
+}
+
+// Load restores a RoutingTable and store.Store from the persister's path.
+//
+// Returns:
+//   - *routing.RoutingTable: The restored routing table.
+//   - store.Store: The restored record store.
+//   - error: Non-nil if the file could not be opened or did not contain a
+//     valid snapshot.
+func (p *FilePersister) Load() (*routing.RoutingTable, store.Store, error) {
+	f, err := os.Open(p.path)
+	if err != nil {
+		return nil, nil, err
+	}
+	defer f.Close()
+
+	return Restore(f)
+}
+
+// Start periodically calls Save for rt and s every interval, until ctx is
+// done.
+//
+// Parameters:
+//   - ctx context.Context: Governs the loop's lifetime.
+//   - interval time.Duration: The delay between auto-saves.
+//   - rt *routing.RoutingTable: The routing table to snapshot on every tick.
+//   - s store.Store: The record store to snapshot on every tick.
+//
+// Returns:
+//   - error: ctx.Err() once ctx is done, or the first error Save returns.
+func (p *FilePersister) Start(ctx context.Context, interval time.Duration, rt *routing.RoutingTable, s store.Store) error {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-ticker.C:
+			if err := p.Save(rt, s); err != nil {
+				return err
+			}
+		}
+	}
+}
@@ -0,0 +1,58 @@
+package persistence_test
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/BochkovDev/kademlia-go/node"
+	"github.com/BochkovDev/kademlia-go/persistence"
+	"github.com/BochkovDev/kademlia-go/routing"
+	"github.com/BochkovDev/kademlia-go/store"
+)
+
+// TestFilePersisterSaveLoad checks that a FilePersister can save a snapshot
+// and load back an equivalent routing table and store.
+func TestFilePersisterSaveLoad(t *testing.T) {
+	local := node.NewNode([]byte("local"), nil, 1)
+	peer := node.NewNode([]byte("peer"), nil, 1)
+
+	rt := routing.NewRoutingTable(local.ID(), 20)
+	rt.Add(peer)
+	ms := store.NewMemoryStore(0, time.Minute, time.Hour)
+
+	path := filepath.Join(t.TempDir(), "snapshot.bin")
+	p := persistence.NewFilePersister(path)
+
+	if err := p.Save(rt, ms); err != nil {
+		t.Fatalf("Save failed: %v", err)
+	}
+
+	restoredRT, _, err := p.Load()
+	if err != nil {
+		t.Fatalf("Load failed: %v", err)
+	}
+	if got := restoredRT.Closest(peer.ID(), 1); len(got) != 1 || !got[0].ID().Equals(peer.ID()) {
+		t.Errorf("Load failed, expected peer %s in restored routing table", peer.ID())
+	}
+}
+
+// TestFilePersisterStartStopsOnContextCancel checks that Start returns once
+// its context is cancelled.
+func TestFilePersisterStartStopsOnContextCancel(t *testing.T) {
+	local := node.NewNode([]byte("local"), nil, 1)
+	rt := routing.NewRoutingTable(local.ID(), 20)
+	ms := store.NewMemoryStore(0, time.Minute, time.Hour)
+
+	path := filepath.Join(t.TempDir(), "snapshot.bin")
+	p := persistence.NewFilePersister(path)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+
+	err := p.Start(ctx, 5*time.Millisecond, rt, ms)
+	if err == nil {
+		t.Error("Start failed, expected an error once the context was done")
+	}
+}
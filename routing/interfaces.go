@@ -1,6 +1,25 @@
 package routing
 
-import "github.com/BochkovDev/kademlia-go/node"
+import (
+	"context"
+
+	"github.com/BochkovDev/kademlia-go/node"
+)
+
+// Pinger checks whether a node is still alive, allowing a KBucket to
+// implement the liveness-aware eviction policy from Section 2.2 of the
+// Kademlia paper instead of blindly dropping the least-recently-seen node.
+//
+// Methods:
+//
+//   - Ping(ctx context.Context, n node.INode) error:
+//     Attempts to reach n and returns a non-nil error if it failed to
+//     respond before ctx is done. Implementations are expected to perform
+//     network I/O, so callers must never invoke Ping while holding a
+//     KBucket's internal lock.
+type Pinger interface {
+	Ping(ctx context.Context, n node.INode) error
+}
 
 // IKBucket defines the interface for managing a K-bucket in the Kademlia DHT routing table.
 //
@@ -23,11 +42,11 @@ import "github.com/BochkovDev/kademlia-go/node"
 //     Adds a new node to the K-bucket. If the bucket is full, this method may replace the least
 //     recently seen node depending on the protocol's eviction policy.
 //
-//   - Remove(id node.NodeID):
+//   - Remove(id node.ID):
 //     Removes a node from the K-bucket based on its unique identifier. This is used to
 //     discard unreachable or outdated nodes, maintaining the relevance of nodes in the bucket.
 //
-//   - Contains(id node.NodeID) bool:
+//   - Contains(id node.ID) bool:
 //     Checks if a node with the given identifier exists in the K-bucket. This is helpful for
 //     avoiding duplicate entries and quickly locating nodes within the bucket.
 //
@@ -50,8 +69,8 @@ type IKBucket interface {
 	Nodes() []*node.INode
 	KSize() uint8
 	Add(newNode *node.INode)
-	Remove(id node.NodeID)
-	Contains(id node.NodeID) bool
+	Remove(id node.ID)
+	Contains(id node.ID) bool
 	IsFull() bool
 	Size() uint8
 	Clear()
@@ -1,7 +1,9 @@
 package routing
 
 import (
+	"context"
 	"sync"
+	"time"
 
 	"github.com/BochkovDev/kademlia-go/node"
 )
@@ -26,6 +28,37 @@ type KBucket struct {
 	// when adding a new node, the oldest node is evicted to make room for the new node.
 	ksize uint8
 
+	// pinger, when non-nil, enables the liveness-aware eviction policy: the
+	// least-recently-seen node is pinged before being evicted rather than
+	// dropped unconditionally. A nil pinger preserves the original
+	// unconditional-eviction behavior.
+	pinger Pinger
+
+	// pingTimeout bounds how long a liveness check of the head node may take
+	// before it is considered unreachable.
+	pingTimeout time.Duration
+
+	// replacements is a bounded FIFO of nodes that arrived while the bucket
+	// was full and a liveness check of the head node was still pending, or
+	// that lost a liveness race. The newest entry is promoted into the
+	// bucket when a node is later removed, or when a pinged head turns out
+	// to be dead.
+	replacements []node.INode
+
+	// replacementCacheSize bounds the length of replacements.
+	replacementCacheSize int
+
+	// pinging reports whether a liveness check of the head node is already
+	// in flight, so that a burst of Add calls against a full bucket only
+	// triggers a single outstanding ping.
+	pinging bool
+
+	// lastSeen records, by ID string, the last time each node currently in
+	// the bucket was added or confirmed alive by a liveness check. It is
+	// nil for buckets constructed with NewKBucket, which have no liveness
+	// tracking to report.
+	lastSeen map[string]time.Time
+
 	// mu is a mutex used to synchronize access to the nodes slice, ensuring that all operations
 	// on the KBucket are thread-safe in concurrent environments.
 	mu sync.Mutex
@@ -48,6 +81,34 @@ func NewKBucket(ksize uint8) *KBucket {
 	}
 }
 
+// NewKBucketWithPinger creates a KBucket that pings its least-recently-seen
+// node before evicting it, per Section 2.2 of the Kademlia paper, instead of
+// evicting unconditionally.
+//
+// Parameters:
+//   - ksize uint8: The maximum number of nodes (K) that this KBucket can hold.
+//   - pinger Pinger: Used to check whether the head node is still alive when
+//     the bucket is full and a new node arrives.
+//   - replacementCacheSize int: The maximum number of candidate nodes held in
+//     the replacement cache while a liveness check is pending or the bucket
+//     stays full.
+//   - pingTimeout time.Duration: The maximum time allowed for a single Ping
+//     call to the head node.
+//
+// Returns:
+//   - *KBucket: A pointer to a newly created, liveness-aware KBucket.
+func NewKBucketWithPinger(ksize uint8, pinger Pinger, replacementCacheSize int, pingTimeout time.Duration) *KBucket {
+	return &KBucket{
+		nodes:                make([]node.INode, 0, ksize),
+		ksize:                ksize,
+		pinger:               pinger,
+		pingTimeout:          pingTimeout,
+		replacementCacheSize: replacementCacheSize,
+		lastSeen:             make(map[string]time.Time),
+		mu:                   sync.Mutex{},
+	}
+}
+
 // Nodes returns a slice of nodes stored in the KBucket.
 //
 // This method provides access to the nodes contained within the KBucket, representing peers
@@ -72,9 +133,17 @@ func (kb *KBucket) KSize() uint8 {
 
 // Add inserts a new node into the KBucket.
 //
-// If the node already exists, it is removed from its current position and re-added to the end
-// of the list to reflect its recent activity. If the KBucket is full and does not contain the new node,
-// the oldest node (at the beginning) is removed to make space.
+// If the node already exists, its entry is moved to the end of the list to reflect its recent
+// activity. Rather than being replaced wholesale, the existing entry absorbs the incoming node's
+// endpoints (see mergeEndpoints), so addresses learned in an earlier sighting are not discarded
+// when the peer is re-seen from a different address. If the KBucket is full and was constructed with
+// NewKBucket, the oldest node (at the beginning) is removed unconditionally to make space.
+//
+// If the KBucket was constructed with NewKBucketWithPinger instead, a full bucket no longer
+// evicts blindly: the head node is pinged in the background (Add never blocks on network I/O)
+// and the new node is queued in the replacement cache in the meantime. If the head answers, it
+// is moved to the tail and the new node stays queued; if it fails to answer, the head is evicted
+// and the newest queued candidate is admitted.
 //
 // Parameters:
 //   - newNode node.INode: The node to be added to the KBucket.
@@ -88,40 +157,205 @@ func (kb *KBucket) KSize() uint8 {
 // [Maymounkov, Petar; Mazieres, David. "Kademlia: A Peer-to-peer Information System Based on the XOR Metric"]: https://pdos.csail.mit.edu/~petar/papers/maymounkov-kademlia-lncs.pdf
 func (kb *KBucket) Add(newNode node.INode) {
 	kb.mu.Lock()
-	defer kb.mu.Unlock()
 
 	for i, n := range kb.nodes {
 		if n.ID().Equals(newNode.ID()) {
+			merged := mergeEndpoints(n, newNode)
 			kb.nodes = append(kb.nodes[:i], kb.nodes[i+1:]...)
-			kb.nodes = append(kb.nodes, newNode)
+			kb.nodes = append(kb.nodes, merged)
+			kb.touch(merged.ID())
+			kb.mu.Unlock()
 			return
 		}
 	}
 
-	if len(kb.nodes) >= int(kb.ksize) {
+	if len(kb.nodes) < int(kb.ksize) {
+		kb.nodes = append(kb.nodes, newNode)
+		kb.touch(newNode.ID())
+		kb.mu.Unlock()
+		return
+	}
+
+	if kb.pinger == nil {
 		kb.nodes = kb.nodes[1:]
+		kb.nodes = append(kb.nodes, newNode)
+		kb.touch(newNode.ID())
+		kb.mu.Unlock()
+		return
+	}
+
+	kb.enqueueReplacement(newNode)
+
+	if kb.pinging {
+		kb.mu.Unlock()
+		return
+	}
+
+	kb.pinging = true
+	head := kb.nodes[0]
+	kb.mu.Unlock()
+
+	go kb.pingHead(head)
+}
+
+// pingHead checks whether head is still alive and reconciles the result
+// under kb.mu: a dead head is evicted in favor of the newest replacement
+// candidate, while a live head is moved to the tail of the bucket. It runs
+// in its own goroutine so that Add never blocks on network I/O.
+func (kb *KBucket) pingHead(head node.INode) {
+	ctx, cancel := context.WithTimeout(context.Background(), kb.pingTimeout)
+	defer cancel()
+
+	err := kb.pinger.Ping(ctx, head)
+
+	kb.mu.Lock()
+	defer kb.mu.Unlock()
+
+	kb.pinging = false
+
+	if len(kb.nodes) == 0 || !kb.nodes[0].ID().Equals(head.ID()) {
+		return
+	}
+
+	if err != nil {
+		kb.nodes = kb.nodes[1:]
+		delete(kb.lastSeen, head.ID().String())
+		if candidate, ok := kb.promoteReplacement(); ok {
+			kb.nodes = append(kb.nodes, candidate)
+			kb.touch(candidate.ID())
+		}
+		return
+	}
+
+	kb.nodes = append(kb.nodes[1:], head)
+	kb.touch(head.ID())
+}
+
+// touch records id as last seen now. Callers must hold kb.mu. It is a no-op
+// for buckets constructed with NewKBucket, which do not track lastSeen.
+func (kb *KBucket) touch(id node.ID) {
+	if kb.lastSeen == nil {
+		return
+	}
+	kb.lastSeen[id.String()] = time.Now()
+}
+
+// LastSeen reports the last time the node with the given ID was added to or
+// confirmed alive in the bucket.
+//
+// Parameters:
+//   - id node.ID: The NodeID to look up.
+//
+// Returns:
+//   - time.Time: The last-seen timestamp, valid only if ok is true.
+//   - bool: True if id has a recorded last-seen time. Always false for a
+//     bucket that does not track liveness at all (see NewKBucket).
+func (kb *KBucket) LastSeen(id node.ID) (time.Time, bool) {
+	kb.mu.Lock()
+	defer kb.mu.Unlock()
+
+	t, ok := kb.lastSeen[id.String()]
+	return t, ok
+}
+
+// enqueueReplacement pushes n onto the replacement cache, evicting any
+// existing entry for the same NodeID first and trimming the oldest entry
+// once replacementCacheSize is exceeded. Callers must hold kb.mu.
+func (kb *KBucket) enqueueReplacement(n node.INode) {
+	for i, r := range kb.replacements {
+		if r.ID().Equals(n.ID()) {
+			kb.replacements = append(kb.replacements[:i], kb.replacements[i+1:]...)
+			break
+		}
 	}
 
-	kb.nodes = append(kb.nodes, newNode)
+	kb.replacements = append(kb.replacements, n)
+	if kb.replacementCacheSize > 0 && len(kb.replacements) > kb.replacementCacheSize {
+		kb.replacements = kb.replacements[1:]
+	}
+}
+
+// promoteReplacement removes and returns the newest entry in the replacement
+// cache. Callers must hold kb.mu.
+func (kb *KBucket) promoteReplacement() (node.INode, bool) {
+	if len(kb.replacements) == 0 {
+		return nil, false
+	}
+
+	n := kb.replacements[len(kb.replacements)-1]
+	kb.replacements = kb.replacements[:len(kb.replacements)-1]
+	return n, true
+}
+
+// Replacements returns the nodes currently queued in the replacement cache,
+// ordered from oldest to newest.
+//
+// Returns:
+//   - []node.INode: The replacement cache's current contents.
+func (kb *KBucket) Replacements() []node.INode {
+	kb.mu.Lock()
+	defer kb.mu.Unlock()
+
+	return kb.replacements
+}
+
+// PromoteReplacement moves a specific node out of the replacement cache and
+// into the bucket, provided the bucket has free capacity. Unlike the
+// automatic promotion performed by pingHead and Remove, which always admits
+// the newest candidate, this lets a caller promote a particular node it has
+// independent reason to believe is alive.
+//
+// Parameters:
+//   - id node.ID: The NodeID of the replacement cache entry to promote.
+//
+// Returns:
+//   - bool: True if id was found in the replacement cache and the bucket had
+//     room to admit it.
+func (kb *KBucket) PromoteReplacement(id node.ID) bool {
+	kb.mu.Lock()
+	defer kb.mu.Unlock()
+
+	if len(kb.nodes) >= int(kb.ksize) {
+		return false
+	}
+
+	for i, r := range kb.replacements {
+		if r.ID().Equals(id) {
+			kb.replacements = append(kb.replacements[:i], kb.replacements[i+1:]...)
+			kb.nodes = append(kb.nodes, r)
+			kb.touch(r.ID())
+			return true
+		}
+	}
+	return false
 }
 
 // Remove deletes a node from the KBucket based on its NodeID.
 //
 // This method searches for the node with the specified NodeID in the KBucket. If found,
-// it removes the node from the list, maintaining the order of remaining nodes.
+// it removes the node from the list, maintaining the order of remaining nodes. If the KBucket
+// was constructed with NewKBucketWithPinger and the removal freed up a slot, the newest entry
+// in the replacement cache is promoted into the bucket.
 //
 // Parameters:
-//   - id node.NodeID: The NodeID of the node to be removed from the KBucket.
+//   - id node.ID: The NodeID of the node to be removed from the KBucket.
 //
 // Notes:
 //   - This method uses a mutex to ensure thread safety while modifying the list of nodes.
-func (kb *KBucket) Remove(id node.NodeID) {
+func (kb *KBucket) Remove(id node.ID) {
 	kb.mu.Lock()
 	defer kb.mu.Unlock()
 
 	for i, n := range kb.nodes {
 		if n.ID().Equals(id) {
 			kb.nodes = append(kb.nodes[:i], kb.nodes[i+1:]...)
+			delete(kb.lastSeen, id.String())
+			if kb.pinger != nil {
+				if candidate, ok := kb.promoteReplacement(); ok {
+					kb.nodes = append(kb.nodes, candidate)
+					kb.touch(candidate.ID())
+				}
+			}
 			return
 		}
 	}
@@ -134,14 +368,14 @@ func (kb *KBucket) Remove(id node.NodeID) {
 // KBucket. Otherwise, it returns false.
 //
 // Parameters:
-//   - id node.NodeID: The NodeID of the node to be checked for presence in the KBucket.
+//   - id node.ID: The NodeID of the node to be checked for presence in the KBucket.
 //
 // Returns:
 //   - bool: Returns true if the node with the specified NodeID exists in the KBucket, false otherwise.
 //
 // Notes:
 //   - This method uses a mutex to ensure thread safety while modifying the list of nodes.
-func (kb *KBucket) Contains(id node.NodeID) bool {
+func (kb *KBucket) Contains(id node.ID) bool {
 	kb.mu.Lock()
 	defer kb.mu.Unlock()
 
@@ -203,3 +437,28 @@ func (kb *KBucket) Clear() {
 
 	kb.nodes = nil
 }
+
+// mergeEndpoints returns the node Add should keep in the bucket for a
+// re-seen NodeID. If both existing and incoming are *node.Node with a
+// populated AddressBook, incoming's endpoints are unioned into existing's
+// AddressBook and existing is returned, so the peer's previously known
+// addresses survive being re-seen from a new one. Otherwise incoming is
+// returned unchanged, preserving the original replace-wholesale behavior
+// for INode implementations that don't carry an AddressBook.
+func mergeEndpoints(existing, incoming node.INode) node.INode {
+	existingNode, ok := existing.(*node.Node)
+	if !ok || existingNode.Endpoints() == nil {
+		return incoming
+	}
+
+	incomingNode, ok := incoming.(*node.Node)
+	if !ok || incomingNode.Endpoints() == nil {
+		return incoming
+	}
+
+	for _, ep := range incomingNode.Endpoints().All() {
+		existingNode.Endpoints().Add(ep)
+	}
+
+	return existingNode
+}
@@ -0,0 +1,173 @@
+package routing_test
+
+import (
+	"net"
+	"testing"
+	"time"
+
+	"github.com/BochkovDev/kademlia-go/node"
+	"github.com/BochkovDev/kademlia-go/routing"
+
+	"github.com/stretchr/testify/suite"
+)
+
+// KBucketPingerTestSuite tests the liveness-aware eviction policy enabled by
+// NewKBucketWithPinger.
+type KBucketPingerTestSuite struct {
+	suite.Suite
+	node1 *node.Node
+	node2 *node.Node
+	node3 *node.Node
+	node4 *node.Node
+}
+
+func (suite *KBucketPingerTestSuite) SetupTest() {
+	suite.node1 = node.NewNodeFromID(node.NewNodeID(node.SHA1Hasher{}, []byte("node_1")), nil, 0)
+	suite.node2 = node.NewNodeFromID(node.NewNodeID(node.SHA1Hasher{}, []byte("node_2")), nil, 0)
+	suite.node3 = node.NewNodeFromID(node.NewNodeID(node.SHA1Hasher{}, []byte("node_3")), nil, 0)
+	suite.node4 = node.NewNodeFromID(node.NewNodeID(node.SHA1Hasher{}, []byte("node_4")), nil, 0)
+}
+
+// TestLiveHeadIsMovedToTail checks that a responsive head node is kept and
+// moved to the tail, while the newcomer waits in the replacement cache.
+func (suite *KBucketPingerTestSuite) TestLiveHeadIsMovedToTail() {
+	pinger := newFakePinger() // every node answers
+	kb := routing.NewKBucketWithPinger(3, pinger, 4, time.Second)
+
+	kb.Add(suite.node1)
+	kb.Add(suite.node2)
+	kb.Add(suite.node3)
+	kb.Add(suite.node4)
+
+	suite.Eventually(func() bool {
+		return pinger.callCount() > 0
+	}, time.Second, time.Millisecond)
+
+	suite.Eventually(func() bool {
+		return kb.Contains(suite.node1.ID()) && !kb.Contains(suite.node4.ID())
+	}, time.Second, time.Millisecond)
+
+	replacements := kb.Replacements()
+	suite.Require().Len(replacements, 1)
+	suite.True(replacements[0].ID().Equals(suite.node4.ID()))
+}
+
+// TestDeadHeadIsEvicted checks that a head node which fails to respond is
+// evicted and the newcomer admitted.
+func (suite *KBucketPingerTestSuite) TestDeadHeadIsEvicted() {
+	pinger := newFakePinger(suite.node1.ID()) // node1 never answers
+	kb := routing.NewKBucketWithPinger(3, pinger, 4, time.Second)
+
+	kb.Add(suite.node1)
+	kb.Add(suite.node2)
+	kb.Add(suite.node3)
+	kb.Add(suite.node4)
+
+	suite.Eventually(func() bool {
+		return !kb.Contains(suite.node1.ID()) && kb.Contains(suite.node4.ID())
+	}, time.Second, time.Millisecond)
+
+	suite.Equal(uint8(3), kb.Size())
+}
+
+// TestRemovePromotesReplacement checks that removing a node promotes the
+// newest replacement cache entry into the freed slot.
+func (suite *KBucketPingerTestSuite) TestRemovePromotesReplacement() {
+	pinger := newFakePinger() // every node answers, nothing evicted by pings
+	kb := routing.NewKBucketWithPinger(2, pinger, 4, time.Second)
+
+	kb.Add(suite.node1)
+	kb.Add(suite.node2)
+	kb.Add(suite.node3) // bucket full: node3 queued as a replacement
+
+	suite.Eventually(func() bool {
+		return len(kb.Replacements()) == 1
+	}, time.Second, time.Millisecond)
+
+	kb.Remove(suite.node1.ID())
+
+	suite.True(kb.Contains(suite.node3.ID()), "KBucket should promote the replacement after a removal")
+	suite.Empty(kb.Replacements())
+}
+
+// TestPromoteReplacementUnknownID checks that PromoteReplacement reports
+// failure for an ID that is not currently queued in the replacement cache.
+func (suite *KBucketPingerTestSuite) TestPromoteReplacementUnknownID() {
+	pinger := newFakePinger()
+	kb := routing.NewKBucketWithPinger(2, pinger, 4, time.Second)
+
+	kb.Add(suite.node1)
+	kb.Add(suite.node2)
+
+	suite.False(kb.PromoteReplacement(suite.node3.ID()))
+}
+
+// TestPromoteReplacementRequiresFreeCapacity checks that PromoteReplacement
+// refuses to admit a cached candidate while the bucket is still full, even
+// though the candidate is present in the replacement cache.
+func (suite *KBucketPingerTestSuite) TestPromoteReplacementRequiresFreeCapacity() {
+	pinger := newFakePinger() // every node answers, nothing evicted by pings
+	kb := routing.NewKBucketWithPinger(2, pinger, 4, time.Second)
+
+	kb.Add(suite.node1)
+	kb.Add(suite.node2)
+	kb.Add(suite.node3) // bucket full: node3 queued as a replacement
+
+	suite.Eventually(func() bool {
+		return len(kb.Replacements()) == 1
+	}, time.Second, time.Millisecond)
+
+	suite.False(kb.PromoteReplacement(suite.node3.ID()), "PromoteReplacement should refuse to exceed ksize")
+	suite.Len(kb.Replacements(), 1, "a failed promotion should leave the candidate queued")
+}
+
+// TestLastSeenTracksAddAndRemove checks that LastSeen reports a recent
+// timestamp for a node added to the bucket, and reports ok=false once the
+// node is removed.
+func (suite *KBucketPingerTestSuite) TestLastSeenTracksAddAndRemove() {
+	pinger := newFakePinger() // every node answers
+	kb := routing.NewKBucketWithPinger(3, pinger, 4, time.Second)
+
+	before := time.Now()
+	kb.Add(suite.node1)
+
+	seen, ok := kb.LastSeen(suite.node1.ID())
+	suite.True(ok)
+	suite.False(seen.Before(before))
+
+	kb.Remove(suite.node1.ID())
+	_, ok = kb.LastSeen(suite.node1.ID())
+	suite.False(ok, "LastSeen should not report a removed node")
+}
+
+func TestKBucketPingerTestSuite(t *testing.T) {
+	suite.Run(t, new(KBucketPingerTestSuite))
+}
+
+// TestKBucketAddMergesEndpointsOnReseen checks that re-adding a known
+// NodeID unions the incoming node's endpoints into the existing entry
+// rather than replacing it wholesale.
+func TestKBucketAddMergesEndpointsOnReseen(t *testing.T) {
+	kb := routing.NewKBucket(3)
+
+	lan := node.NewNode([]byte("peer"), net.ParseIP("192.168.1.1"), 6881)
+	kb.Add(lan)
+
+	wan := node.NewNode([]byte("peer"), net.ParseIP("203.0.113.7"), 6881)
+	kb.Add(wan)
+
+	if got := kb.Size(); got != 1 {
+		t.Fatalf("Add() failed to merge re-seen NodeID, expected size 1, got %d", got)
+	}
+
+	nodes := kb.Nodes()
+	stored, ok := nodes[0].(*node.Node)
+	if !ok {
+		t.Fatalf("Add() failed, stored node is not a *node.Node")
+	}
+
+	endpoints := stored.Endpoints().All()
+	if len(endpoints) != 2 {
+		t.Fatalf("Add() failed to union endpoints, expected 2, got %d", len(endpoints))
+	}
+}
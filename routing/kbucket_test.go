@@ -22,11 +22,11 @@ type KBucketTestSuite struct {
 
 // SetupTest initializes the necessary data before each test
 func (suite *KBucketTestSuite) SetupTest() {
-	suite.node1 = &node.Node{ID: node.NewNodeID([]byte("node_1"))}
-	suite.node2 = &node.Node{ID: node.NewNodeID([]byte("node_2"))}
-	suite.node3 = &node.Node{ID: node.NewNodeID([]byte("node_3"))}
-	suite.node4 = &node.Node{ID: node.NewNodeID([]byte("node_4"))}
-	suite.kb = &routing.KBucket{MaxSize: 3}
+	suite.node1 = node.NewNodeFromID(node.NewNodeID(node.SHA1Hasher{}, []byte("node_1")), nil, 0)
+	suite.node2 = node.NewNodeFromID(node.NewNodeID(node.SHA1Hasher{}, []byte("node_2")), nil, 0)
+	suite.node3 = node.NewNodeFromID(node.NewNodeID(node.SHA1Hasher{}, []byte("node_3")), nil, 0)
+	suite.node4 = node.NewNodeFromID(node.NewNodeID(node.SHA1Hasher{}, []byte("node_4")), nil, 0)
+	suite.kb = routing.NewKBucket(3)
 }
 
 // TearDownTest clears the KBucket after each test
@@ -40,8 +40,8 @@ func (suite *KBucketTestSuite) TestAddNode() {
 	suite.kb.Add(suite.node2)
 
 	suite.Equal(uint8(2), suite.kb.Size(), "Expected KBucket size to be 2")
-	suite.True(suite.kb.Contains(suite.node1.ID), "KBucket should contain node1")
-	suite.True(suite.kb.Contains(suite.node2.ID), "KBucket should contain node2")
+	suite.True(suite.kb.Contains(suite.node1.ID()), "KBucket should contain node1")
+	suite.True(suite.kb.Contains(suite.node2.ID()), "KBucket should contain node2")
 }
 
 // TestAddNodeEviction tests adding a new node when the KBucket is full
@@ -56,10 +56,10 @@ func (suite *KBucketTestSuite) TestAddNodeEviction() {
 	// Adding a new node should evict the oldest one (node1).
 	suite.kb.Add(suite.node4)
 	suite.Equal(uint8(3), suite.kb.Size(), "Expected KBucket size to be 3 after eviction")
-	suite.False(suite.kb.Contains(suite.node1.ID), "KBucket should not contain node1 after eviction")
-	suite.True(suite.kb.Contains(suite.node2.ID), "KBucket should contain node2")
-	suite.True(suite.kb.Contains(suite.node3.ID), "KBucket should contain node3")
-	suite.True(suite.kb.Contains(suite.node4.ID), "KBucket should contain node4")
+	suite.False(suite.kb.Contains(suite.node1.ID()), "KBucket should not contain node1 after eviction")
+	suite.True(suite.kb.Contains(suite.node2.ID()), "KBucket should contain node2")
+	suite.True(suite.kb.Contains(suite.node3.ID()), "KBucket should contain node3")
+	suite.True(suite.kb.Contains(suite.node4.ID()), "KBucket should contain node4")
 }
 
 // TestRemoveNode tests removing nodes from the KBucket
@@ -67,17 +67,17 @@ func (suite *KBucketTestSuite) TestRemoveNode() {
 	suite.kb.Add(suite.node1)
 	suite.kb.Add(suite.node2)
 
-	suite.True(suite.kb.Contains(suite.node1.ID), "KBucket should contain node1")
-	suite.kb.Remove(suite.node1.ID)
-	suite.False(suite.kb.Contains(suite.node1.ID), "KBucket should not contain node1 after removal")
+	suite.True(suite.kb.Contains(suite.node1.ID()), "KBucket should contain node1")
+	suite.kb.Remove(suite.node1.ID())
+	suite.False(suite.kb.Contains(suite.node1.ID()), "KBucket should not contain node1 after removal")
 }
 
 // TestContainsNode tests if a node is contained in the KBucket
 func (suite *KBucketTestSuite) TestContainsNode() {
 	suite.kb.Add(suite.node1)
 
-	suite.True(suite.kb.Contains(suite.node1.ID), "KBucket should contain node1")
-	suite.False(suite.kb.Contains(suite.node2.ID), "KBucket should not contain node2")
+	suite.True(suite.kb.Contains(suite.node1.ID()), "KBucket should contain node1")
+	suite.False(suite.kb.Contains(suite.node2.ID()), "KBucket should not contain node2")
 }
 
 // TestIsFull tests checking if the KBucket is full
@@ -109,8 +109,8 @@ func (suite *KBucketTestSuite) TestClear() {
 	suite.kb.Clear()
 
 	suite.Equal(uint8(0), suite.kb.Size(), "Expected KBucket size to be 0 after clearing")
-	suite.False(suite.kb.Contains(suite.node1.ID), "KBucket should not contain node1 after clearing")
-	suite.False(suite.kb.Contains(suite.node2.ID), "KBucket should not contain node2 after clearing")
+	suite.False(suite.kb.Contains(suite.node1.ID()), "KBucket should not contain node1 after clearing")
+	suite.False(suite.kb.Contains(suite.node2.ID()), "KBucket should not contain node2 after clearing")
 }
 
 // TestKBucketTestSuite runs the test suite
@@ -119,33 +119,27 @@ func TestKBucketTestSuite(t *testing.T) {
 }
 
 func TestKBucketAddConcurrency(t *testing.T) {
-	kb := &routing.KBucket{
-		Nodes:   []*node.Node{},
-		MaxSize: 10,
-	}
+	kb := routing.NewKBucket(10)
 
 	var wg sync.WaitGroup
 	for i := 0; i < 1000; i++ {
 		wg.Add(1)
 		go func(i int) {
 			defer wg.Done()
-			kb.Add(&node.Node{ID: node.NewNodeID([]byte{byte(i)})})
+			kb.Add(node.NewNodeFromID(node.NewNodeID(node.SHA1Hasher{}, []byte{byte(i)}), nil, 0))
 		}(i)
 	}
 
 	wg.Wait()
 
-	if kb.Size() > kb.MaxSize {
-		t.Errorf("KBucket contains more than MaxSize nodes. Size: %d", kb.Size())
+	if kb.Size() > kb.KSize() {
+		t.Errorf("KBucket contains more than KSize nodes. Size: %d", kb.Size())
 	}
 }
 
 // TestKBucketAddRemoveConcurrency verifies the KBucket's behavior when adding and removing nodes concurrently.
 func TestKBucketAddRemoveConcurrency(t *testing.T) {
-	kb := &routing.KBucket{
-		Nodes:   []*node.Node{},
-		MaxSize: 10,
-	}
+	kb := routing.NewKBucket(10)
 
 	var wg sync.WaitGroup
 	numOperations := 100
@@ -154,27 +148,24 @@ func TestKBucketAddRemoveConcurrency(t *testing.T) {
 		wg.Add(1)
 		go func(i int) {
 			defer wg.Done()
-			nodeID := node.NewNodeID([]byte{byte(i % 256)})
-			kb.Add(&node.Node{ID: nodeID})
+			nodeID := node.NewNodeID(node.SHA1Hasher{}, []byte{byte(i % 256)})
+			kb.Add(node.NewNodeFromID(nodeID, nil, 0))
 			kb.Remove(nodeID)
 		}(i)
 	}
 
 	wg.Wait()
 
-	if kb.Size() > uint8(kb.MaxSize) {
-		t.Errorf("KBucket size exceeds MaxSize: got %d, expected <= %d", kb.Size(), kb.MaxSize)
+	if kb.Size() > kb.KSize() {
+		t.Errorf("KBucket size exceeds KSize: got %d, expected <= %d", kb.Size(), kb.KSize())
 	}
 }
 
 func TestKBucketConcurrentClear(t *testing.T) {
-	kb := &routing.KBucket{
-		Nodes:   []*node.Node{},
-		MaxSize: 10,
-	}
+	kb := routing.NewKBucket(10)
 
 	for i := 0; i < 10; i++ {
-		kb.Add(&node.Node{ID: node.NewNodeID([]byte{byte(i)})})
+		kb.Add(node.NewNodeFromID(node.NewNodeID(node.SHA1Hasher{}, []byte{byte(i)}), nil, 0))
 	}
 
 	var wg sync.WaitGroup
@@ -195,13 +186,10 @@ func TestKBucketConcurrentClear(t *testing.T) {
 
 // TestKBucketConcurrentContains tests the Contains method with concurrent access.
 func TestKBucketConcurrentContains(t *testing.T) {
-	kb := &routing.KBucket{
-		Nodes:   []*node.Node{},
-		MaxSize: 10,
-	}
+	kb := routing.NewKBucket(10)
 
 	for i := 0; i < 10; i++ {
-		kb.Add(&node.Node{ID: node.NewNodeID([]byte{byte(i)})})
+		kb.Add(node.NewNodeFromID(node.NewNodeID(node.SHA1Hasher{}, []byte{byte(i)}), nil, 0))
 	}
 
 	var wg sync.WaitGroup
@@ -209,7 +197,7 @@ func TestKBucketConcurrentContains(t *testing.T) {
 		wg.Add(1)
 		go func(i int) {
 			defer wg.Done()
-			nodeID := node.NewNodeID([]byte{byte(i % 256)})
+			nodeID := node.NewNodeID(node.SHA1Hasher{}, []byte{byte(i % 256)})
 			_ = kb.Contains(nodeID)
 		}(i)
 	}
@@ -0,0 +1,43 @@
+package routing_test
+
+import (
+	"context"
+	"errors"
+	"sync"
+
+	"github.com/BochkovDev/kademlia-go/node"
+)
+
+// fakePinger is a test double for routing.Pinger that reports nodes as dead
+// if their NodeID is present in the dead set, and alive otherwise.
+type fakePinger struct {
+	mu    sync.Mutex
+	dead  map[node.ID]bool
+	calls int
+}
+
+func newFakePinger(deadIDs ...node.ID) *fakePinger {
+	dead := make(map[node.ID]bool, len(deadIDs))
+	for _, id := range deadIDs {
+		dead[id] = true
+	}
+	return &fakePinger{dead: dead}
+}
+
+func (p *fakePinger) Ping(ctx context.Context, n node.INode) error {
+	p.mu.Lock()
+	p.calls++
+	isDead := p.dead[n.ID()]
+	p.mu.Unlock()
+
+	if isDead {
+		return errors.New("fakePinger: node did not respond")
+	}
+	return nil
+}
+
+func (p *fakePinger) callCount() int {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return p.calls
+}
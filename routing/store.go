@@ -0,0 +1,333 @@
+package routing
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"sync"
+	"time"
+
+	"github.com/BochkovDev/kademlia-go/node"
+)
+
+// binSnapshotMagic identifies a stream as a kademlia-go routing-table bucket
+// snapshot, so decodeBuckets can reject arbitrary input before attempting to
+// decode it.
+var binSnapshotMagic = [4]byte{'K', 'D', 'R', 'T'}
+
+// binSnapshotVersion is the current binary bucket-snapshot format version,
+// written after binSnapshotMagic so the encoding can evolve; decodeBuckets
+// rejects any version it does not recognize.
+const binSnapshotVersion uint16 = 1
+
+// ErrBadStoreMagic is returned when decoding a binary bucket snapshot that
+// does not begin with binSnapshotMagic.
+var ErrBadStoreMagic = errors.New("routing: not a kademlia-go bucket snapshot")
+
+// ErrUnsupportedStoreVersion is returned when a binary bucket snapshot was
+// written by a newer, incompatible format version.
+var ErrUnsupportedStoreVersion = errors.New("routing: unsupported bucket snapshot version")
+
+// NodeRecord is the persisted form of a single node entry within a bucket
+// snapshot. It captures the metadata a Store needs to warm a routing table
+// on restart without re-discovering every peer through a fresh bootstrap.
+type NodeRecord struct {
+	// ID is the node's NodeID.
+	ID node.ID
+
+	// Address and Port are the node's last known endpoint. Both are zero
+	// if the bucket held an INode implementation other than *node.Node.
+	Address net.IP
+	Port    uint16
+
+	// LastSeen is the last time the bucket saw this node, per
+	// KBucket.LastSeen. It is the zero time if the bucket did not track
+	// liveness (see NewKBucket) or never recorded a sighting.
+	LastSeen time.Time
+}
+
+// BucketRecord is the persisted form of a single KBucket: its capacity and
+// the nodes it held, ordered from least to most recently seen.
+type BucketRecord struct {
+	KSize uint8
+	Nodes []NodeRecord
+}
+
+// Store persists and restores a routing table's buckets to an external
+// storage backend, independent of the RoutingTable that produced them. It
+// mirrors the kadBucketDB/nodeBucketDB split in Storj's routing table: one
+// record tracks which nodes belong in which bucket, the other carries the
+// nodes' own metadata.
+//
+// Store is deliberately narrower than the persistence package:
+// persistence.Snapshot/Restore round-trip a whole node (routing table plus
+// store.Store records) through a single file, for a process that owns both
+// and wants one restart artifact. Store only ever sees buckets, so it suits
+// a caller that wants to warm just the routing tree — e.g. from a key/value
+// backend it is already using for other state, via KVBackend/BoltBackend.
+// The two still agree on how an ID itself is framed on the wire
+// (node.WriteID/node.ReadID); RoutingTable.LoadFrom and persistence.Restore
+// also share the same restore-through-Add rule: see LoadFrom's doc comment.
+//
+// Restoring a KBucket from a Store rebuilds it by adding each NodeRecord
+// back through KBucket.Add in saved order, the same way persistence.Restore
+// re-inserts nodes into a RoutingTable, rather than trusting the saved
+// internal layout as-is. A restored bucket has no pinger and so does not
+// resume liveness-aware eviction; callers that need it should hand the
+// restored nodes to a fresh NewKBucketWithPinger bucket instead.
+type Store interface {
+	// Save persists buckets, in order of increasing common-prefix length
+	// with the owning RoutingTable's local ID, replacing any snapshot
+	// previously saved through this Store.
+	Save(buckets []*KBucket) error
+
+	// Load returns the buckets most recently saved through this Store, in
+	// the same order they were saved. It returns a nil slice and a nil
+	// error if nothing has been saved yet.
+	Load() ([]*KBucket, error)
+}
+
+// snapshotBucket captures b's capacity, nodes, and last-seen metadata as a
+// BucketRecord.
+func snapshotBucket(b *KBucket) BucketRecord {
+	nodes := b.Nodes()
+	rec := BucketRecord{
+		KSize: b.KSize(),
+		Nodes: make([]NodeRecord, 0, len(nodes)),
+	}
+
+	for _, n := range nodes {
+		nr := NodeRecord{ID: n.ID()}
+		if concrete, ok := n.(*node.Node); ok {
+			nr.Address = concrete.Address()
+			nr.Port = concrete.Port()
+		}
+		if seen, ok := b.LastSeen(n.ID()); ok {
+			nr.LastSeen = seen
+		}
+		rec.Nodes = append(rec.Nodes, nr)
+	}
+
+	return rec
+}
+
+// restoreBucket rebuilds a KBucket from a BucketRecord by re-inserting each
+// node through Add in saved order.
+func restoreBucket(rec BucketRecord) *KBucket {
+	b := NewKBucket(rec.KSize)
+
+	for _, nr := range rec.Nodes {
+		b.Add(node.NewNodeFromID(nr.ID, nr.Address, nr.Port))
+	}
+
+	return b
+}
+
+// MemoryStore is an in-process Store that keeps its most recent snapshot as
+// an in-memory byte buffer, round-tripping through the versioned binary
+// encoding of encodeBuckets/decodeBuckets. It is useful for tests and for short-lived
+// processes that want warm-restart behavior without touching disk.
+type MemoryStore struct {
+	mu  sync.Mutex
+	buf []byte
+}
+
+// NewMemoryStore creates an empty MemoryStore.
+//
+// Returns:
+//   - *MemoryStore: A pointer to a newly created MemoryStore.
+func NewMemoryStore() *MemoryStore {
+	return &MemoryStore{}
+}
+
+// Save implements Store.
+func (s *MemoryStore) Save(buckets []*KBucket) error {
+	var buf bytes.Buffer
+	if err := encodeBuckets(&buf, buckets); err != nil {
+		return err
+	}
+
+	s.mu.Lock()
+	s.buf = buf.Bytes()
+	s.mu.Unlock()
+	return nil
+}
+
+// Load implements Store.
+func (s *MemoryStore) Load() ([]*KBucket, error) {
+	s.mu.Lock()
+	buf := s.buf
+	s.mu.Unlock()
+
+	if buf == nil {
+		return nil, nil
+	}
+	return decodeBuckets(bytes.NewReader(buf))
+}
+
+// encodeBuckets writes a versioned binary encoding of buckets to w.
+func encodeBuckets(w io.Writer, buckets []*KBucket) error {
+	bw := bufio.NewWriter(w)
+
+	if _, err := bw.Write(binSnapshotMagic[:]); err != nil {
+		return err
+	}
+	if err := binary.Write(bw, binary.BigEndian, binSnapshotVersion); err != nil {
+		return err
+	}
+	if err := binary.Write(bw, binary.BigEndian, uint32(len(buckets))); err != nil {
+		return err
+	}
+
+	for _, b := range buckets {
+		if err := writeBucketRecord(bw, snapshotBucket(b)); err != nil {
+			return err
+		}
+	}
+
+	return bw.Flush()
+}
+
+// decodeBuckets decodes a snapshot written by encodeBuckets.
+func decodeBuckets(r io.Reader) ([]*KBucket, error) {
+	br := bufio.NewReader(r)
+
+	var gotMagic [4]byte
+	if _, err := io.ReadFull(br, gotMagic[:]); err != nil {
+		return nil, err
+	}
+	if gotMagic != binSnapshotMagic {
+		return nil, ErrBadStoreMagic
+	}
+
+	var gotVersion uint16
+	if err := binary.Read(br, binary.BigEndian, &gotVersion); err != nil {
+		return nil, err
+	}
+	if gotVersion != binSnapshotVersion {
+		return nil, fmt.Errorf("%w: got %d, want %d", ErrUnsupportedStoreVersion, gotVersion, binSnapshotVersion)
+	}
+
+	var bucketCount uint32
+	if err := binary.Read(br, binary.BigEndian, &bucketCount); err != nil {
+		return nil, err
+	}
+
+	buckets := make([]*KBucket, 0, bucketCount)
+	for i := uint32(0); i < bucketCount; i++ {
+		rec, err := readBucketRecord(br)
+		if err != nil {
+			return nil, err
+		}
+		buckets = append(buckets, restoreBucket(rec))
+	}
+
+	return buckets, nil
+}
+
+// writeBucketRecord encodes a single BucketRecord as its capacity followed
+// by its length-prefixed list of node records.
+func writeBucketRecord(w io.Writer, rec BucketRecord) error {
+	if err := binary.Write(w, binary.BigEndian, rec.KSize); err != nil {
+		return err
+	}
+	if err := binary.Write(w, binary.BigEndian, uint32(len(rec.Nodes))); err != nil {
+		return err
+	}
+	for _, nr := range rec.Nodes {
+		if err := writeNodeRecord(w, nr); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// readBucketRecord decodes a single BucketRecord written by
+// writeBucketRecord.
+func readBucketRecord(r io.Reader) (BucketRecord, error) {
+	var rec BucketRecord
+
+	if err := binary.Read(r, binary.BigEndian, &rec.KSize); err != nil {
+		return BucketRecord{}, err
+	}
+
+	var nodeCount uint32
+	if err := binary.Read(r, binary.BigEndian, &nodeCount); err != nil {
+		return BucketRecord{}, err
+	}
+
+	rec.Nodes = make([]NodeRecord, 0, nodeCount)
+	for i := uint32(0); i < nodeCount; i++ {
+		nr, err := readNodeRecord(r)
+		if err != nil {
+			return BucketRecord{}, err
+		}
+		rec.Nodes = append(rec.Nodes, nr)
+	}
+
+	return rec, nil
+}
+
+// writeNodeRecord encodes a single NodeRecord as its length-prefixed ID, a
+// length-prefixed IP address (zero length for a nil address), its port, and
+// its last-seen time as Unix nanoseconds (zero if untracked).
+func writeNodeRecord(w io.Writer, nr NodeRecord) error {
+	if err := node.WriteID(w, nr.ID); err != nil {
+		return err
+	}
+
+	if err := binary.Write(w, binary.BigEndian, uint8(len(nr.Address))); err != nil {
+		return err
+	}
+	if len(nr.Address) > 0 {
+		if _, err := w.Write(nr.Address); err != nil {
+			return err
+		}
+	}
+
+	if err := binary.Write(w, binary.BigEndian, nr.Port); err != nil {
+		return err
+	}
+
+	return binary.Write(w, binary.BigEndian, nr.LastSeen.UnixNano())
+}
+
+// readNodeRecord decodes a single NodeRecord written by writeNodeRecord.
+func readNodeRecord(r io.Reader) (NodeRecord, error) {
+	var nr NodeRecord
+
+	id, err := node.ReadID(r)
+	if err != nil {
+		return NodeRecord{}, err
+	}
+	nr.ID = id
+
+	var addrLen uint8
+	if err := binary.Read(r, binary.BigEndian, &addrLen); err != nil {
+		return NodeRecord{}, err
+	}
+	if addrLen > 0 {
+		nr.Address = make(net.IP, addrLen)
+		if _, err := io.ReadFull(r, nr.Address); err != nil {
+			return NodeRecord{}, err
+		}
+	}
+
+	if err := binary.Read(r, binary.BigEndian, &nr.Port); err != nil {
+		return NodeRecord{}, err
+	}
+
+	var lastSeenNano int64
+	if err := binary.Read(r, binary.BigEndian, &lastSeenNano); err != nil {
+		return NodeRecord{}, err
+	}
+	if lastSeenNano != 0 {
+		nr.LastSeen = time.Unix(0, lastSeenNano).UTC()
+	}
+
+	return nr, nil
+}
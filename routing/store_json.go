@@ -0,0 +1,154 @@
+package routing
+
+import (
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net"
+	"os"
+	"time"
+
+	"github.com/BochkovDev/kademlia-go/node"
+)
+
+// jsonStoreVersion is the current JSON bucket-snapshot format version,
+// stored alongside the buckets so JSONStore.Load can reject a snapshot
+// written by an incompatible future version.
+const jsonStoreVersion = 1
+
+// jsonSnapshot is the on-disk shape written by JSONStore.
+type jsonSnapshot struct {
+	Version int                `json:"version"`
+	Buckets []jsonBucketRecord `json:"buckets"`
+}
+
+type jsonBucketRecord struct {
+	KSize uint8            `json:"ksize"`
+	Nodes []jsonNodeRecord `json:"nodes"`
+}
+
+type jsonNodeRecord struct {
+	ID       string     `json:"id"`
+	Address  string     `json:"address,omitempty"`
+	Port     uint16     `json:"port"`
+	LastSeen *time.Time `json:"last_seen,omitempty"`
+}
+
+// JSONStore is a Store backed by a single human-readable JSON file, useful
+// for small deployments or debugging a saved routing table by hand.
+type JSONStore struct {
+	path string
+}
+
+// NewJSONStore creates a JSONStore that reads and writes its snapshot at
+// path.
+//
+// Parameters:
+//   - path string: The file path the snapshot is read from and written to.
+//
+// Returns:
+//   - *JSONStore: A pointer to a newly created JSONStore.
+func NewJSONStore(path string) *JSONStore {
+	return &JSONStore{path: path}
+}
+
+// Save implements Store, overwriting the file at path with buckets encoded
+// as indented JSON.
+func (s *JSONStore) Save(buckets []*KBucket) error {
+	snap := jsonSnapshot{Version: jsonStoreVersion}
+
+	for _, b := range buckets {
+		snap.Buckets = append(snap.Buckets, toJSONBucketRecord(snapshotBucket(b)))
+	}
+
+	data, err := json.MarshalIndent(snap, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	return os.WriteFile(s.path, data, 0o600)
+}
+
+// Load implements Store, decoding the JSON file at path. It returns a nil
+// slice and a nil error if the file does not exist yet.
+func (s *JSONStore) Load() ([]*KBucket, error) {
+	data, err := os.ReadFile(s.path)
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var snap jsonSnapshot
+	if err := json.Unmarshal(data, &snap); err != nil {
+		return nil, err
+	}
+	if snap.Version != jsonStoreVersion {
+		return nil, fmt.Errorf("%w: got %d, want %d", ErrUnsupportedStoreVersion, snap.Version, jsonStoreVersion)
+	}
+
+	buckets := make([]*KBucket, 0, len(snap.Buckets))
+	for _, jbr := range snap.Buckets {
+		rec, err := fromJSONBucketRecord(jbr)
+		if err != nil {
+			return nil, err
+		}
+		buckets = append(buckets, restoreBucket(rec))
+	}
+
+	return buckets, nil
+}
+
+func toJSONBucketRecord(rec BucketRecord) jsonBucketRecord {
+	jbr := jsonBucketRecord{
+		KSize: rec.KSize,
+		Nodes: make([]jsonNodeRecord, 0, len(rec.Nodes)),
+	}
+
+	for _, nr := range rec.Nodes {
+		jnr := jsonNodeRecord{
+			ID:   hex.EncodeToString(nr.ID.Bytes()),
+			Port: nr.Port,
+		}
+		if nr.Address != nil {
+			jnr.Address = nr.Address.String()
+		}
+		if !nr.LastSeen.IsZero() {
+			lastSeen := nr.LastSeen
+			jnr.LastSeen = &lastSeen
+		}
+		jbr.Nodes = append(jbr.Nodes, jnr)
+	}
+
+	return jbr
+}
+
+func fromJSONBucketRecord(jbr jsonBucketRecord) (BucketRecord, error) {
+	rec := BucketRecord{
+		KSize: jbr.KSize,
+		Nodes: make([]NodeRecord, 0, len(jbr.Nodes)),
+	}
+
+	for _, jnr := range jbr.Nodes {
+		raw, err := hex.DecodeString(jnr.ID)
+		if err != nil {
+			return BucketRecord{}, err
+		}
+		id, err := node.NewIDFromBytes(raw)
+		if err != nil {
+			return BucketRecord{}, err
+		}
+
+		nr := NodeRecord{ID: id, Port: jnr.Port}
+		if jnr.Address != "" {
+			nr.Address = net.ParseIP(jnr.Address)
+		}
+		if jnr.LastSeen != nil {
+			nr.LastSeen = *jnr.LastSeen
+		}
+		rec.Nodes = append(rec.Nodes, nr)
+	}
+
+	return rec, nil
+}
@@ -0,0 +1,247 @@
+package routing
+
+import (
+	"bytes"
+	"encoding/binary"
+	"fmt"
+
+	"github.com/BochkovDev/kademlia-go/node"
+	"go.etcd.io/bbolt"
+)
+
+// KVBackend is the minimal key-value contract KVStore needs from an
+// embedded database: per-bucket Put/Get/ForEach plus wiping a bucket before
+// a fresh Save. BoltBackend implements it against a real BoltDB file;
+// anything with the same shape (including an in-memory fake in tests) can
+// stand in for it.
+type KVBackend interface {
+	Put(bucket, key, value []byte) error
+	Get(bucket, key []byte) ([]byte, bool, error)
+	ForEach(bucket []byte, fn func(key, value []byte) error) error
+	DeleteBucket(bucket []byte) error
+}
+
+// kadBucketDBName and nodeBucketDBName mirror the kadBucketDB/nodeBucketDB
+// split in Storj's routing table: kadBucketDBName maps a bucket's index to
+// the ordered list of NodeIDs it held, while nodeBucketDBName maps a NodeID
+// to that node's own persisted metadata. Splitting the two lets a single
+// node's metadata be updated without rewriting every bucket that might
+// reference it.
+var (
+	kadBucketDBName  = []byte("kad_bucket_db")
+	nodeBucketDBName = []byte("node_bucket_db")
+)
+
+// KVStore is a Store backed by an embedded key-value database, such as
+// BoltDB, via the KVBackend abstraction.
+type KVStore struct {
+	backend KVBackend
+}
+
+// NewKVStore creates a KVStore backed by backend.
+//
+// Parameters:
+//   - backend KVBackend: The key-value database Save and Load read and
+//     write through.
+//
+// Returns:
+//   - *KVStore: A pointer to a newly created KVStore.
+func NewKVStore(backend KVBackend) *KVStore {
+	return &KVStore{backend: backend}
+}
+
+// Save implements Store. Each bucket's node order and capacity are recorded
+// in kadBucketDBName under its index; each node's metadata is recorded in
+// nodeBucketDBName under its NodeID. Both backend buckets are wiped first, so
+// every Save rewrites every bucket entry and every node record.
+func (s *KVStore) Save(buckets []*KBucket) error {
+	if err := s.backend.DeleteBucket(kadBucketDBName); err != nil {
+		return err
+	}
+	if err := s.backend.DeleteBucket(nodeBucketDBName); err != nil {
+		return err
+	}
+
+	for i, b := range buckets {
+		rec := snapshotBucket(b)
+
+		var idList bytes.Buffer
+		if err := binary.Write(&idList, binary.BigEndian, rec.KSize); err != nil {
+			return err
+		}
+		if err := binary.Write(&idList, binary.BigEndian, uint32(len(rec.Nodes))); err != nil {
+			return err
+		}
+
+		for _, nr := range rec.Nodes {
+			if err := node.WriteID(&idList, nr.ID); err != nil {
+				return err
+			}
+
+			var nodeBuf bytes.Buffer
+			if err := writeNodeRecord(&nodeBuf, nr); err != nil {
+				return err
+			}
+			if err := s.backend.Put(nodeBucketDBName, nr.ID.Bytes(), nodeBuf.Bytes()); err != nil {
+				return err
+			}
+		}
+
+		if err := s.backend.Put(kadBucketDBName, bucketIndexKey(i), idList.Bytes()); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// Load implements Store, walking kadBucketDBName from index zero until a
+// missing index ends the sequence, resolving each referenced NodeID against
+// nodeBucketDBName for its metadata.
+func (s *KVStore) Load() ([]*KBucket, error) {
+	var buckets []*KBucket
+
+	for i := 0; ; i++ {
+		value, ok, err := s.backend.Get(kadBucketDBName, bucketIndexKey(i))
+		if err != nil {
+			return nil, err
+		}
+		if !ok {
+			break
+		}
+
+		rec, err := readKVBucketRecord(s.backend, value)
+		if err != nil {
+			return nil, err
+		}
+		buckets = append(buckets, restoreBucket(rec))
+	}
+
+	return buckets, nil
+}
+
+// readKVBucketRecord decodes the kadBucketDBName entry written by Save and
+// resolves each of its NodeIDs against nodeBucketDBName.
+func readKVBucketRecord(backend KVBackend, idList []byte) (BucketRecord, error) {
+	r := bytes.NewReader(idList)
+
+	var rec BucketRecord
+	if err := binary.Read(r, binary.BigEndian, &rec.KSize); err != nil {
+		return BucketRecord{}, err
+	}
+
+	var nodeCount uint32
+	if err := binary.Read(r, binary.BigEndian, &nodeCount); err != nil {
+		return BucketRecord{}, err
+	}
+
+	rec.Nodes = make([]NodeRecord, 0, nodeCount)
+	for n := uint32(0); n < nodeCount; n++ {
+		id, err := node.ReadID(r)
+		if err != nil {
+			return BucketRecord{}, err
+		}
+
+		value, ok, err := backend.Get(nodeBucketDBName, id.Bytes())
+		if err != nil {
+			return BucketRecord{}, err
+		}
+		if !ok {
+			return BucketRecord{}, fmt.Errorf("routing: kv store missing node record for %s", id)
+		}
+
+		nr, err := readNodeRecord(bytes.NewReader(value))
+		if err != nil {
+			return BucketRecord{}, err
+		}
+		rec.Nodes = append(rec.Nodes, nr)
+	}
+
+	return rec, nil
+}
+
+// bucketIndexKey encodes a bucket index as a big-endian uint32, so
+// kadBucketDBName keys sort in bucket order.
+func bucketIndexKey(i int) []byte {
+	key := make([]byte, 4)
+	binary.BigEndian.PutUint32(key, uint32(i))
+	return key
+}
+
+// BoltBackend implements KVBackend against a BoltDB (go.etcd.io/bbolt)
+// database file, creating buckets on demand.
+type BoltBackend struct {
+	db *bbolt.DB
+}
+
+// OpenBoltBackend opens (creating if necessary) a BoltDB file at path as a
+// KVBackend.
+//
+// Parameters:
+//   - path string: The BoltDB file to open.
+//
+// Returns:
+//   - *BoltBackend: A pointer to the opened backend.
+//   - error: Non-nil if the file could not be opened.
+func OpenBoltBackend(path string) (*BoltBackend, error) {
+	db, err := bbolt.Open(path, 0o600, nil)
+	if err != nil {
+		return nil, err
+	}
+	return &BoltBackend{db: db}, nil
+}
+
+// Close closes the underlying BoltDB file.
+func (b *BoltBackend) Close() error {
+	return b.db.Close()
+}
+
+// Put implements KVBackend.
+func (b *BoltBackend) Put(bucket, key, value []byte) error {
+	return b.db.Update(func(tx *bbolt.Tx) error {
+		bkt, err := tx.CreateBucketIfNotExists(bucket)
+		if err != nil {
+			return err
+		}
+		return bkt.Put(key, value)
+	})
+}
+
+// Get implements KVBackend.
+func (b *BoltBackend) Get(bucket, key []byte) ([]byte, bool, error) {
+	var value []byte
+	err := b.db.View(func(tx *bbolt.Tx) error {
+		bkt := tx.Bucket(bucket)
+		if bkt == nil {
+			return nil
+		}
+		if v := bkt.Get(key); v != nil {
+			value = append([]byte(nil), v...)
+		}
+		return nil
+	})
+	return value, value != nil, err
+}
+
+// ForEach implements KVBackend.
+func (b *BoltBackend) ForEach(bucket []byte, fn func(key, value []byte) error) error {
+	return b.db.View(func(tx *bbolt.Tx) error {
+		bkt := tx.Bucket(bucket)
+		if bkt == nil {
+			return nil
+		}
+		return bkt.ForEach(fn)
+	})
+}
+
+// DeleteBucket implements KVBackend, treating a bucket that does not exist
+// as already-deleted rather than an error.
+func (b *BoltBackend) DeleteBucket(bucket []byte) error {
+	return b.db.Update(func(tx *bbolt.Tx) error {
+		err := tx.DeleteBucket(bucket)
+		if err == bbolt.ErrBucketNotFound {
+			return nil
+		}
+		return err
+	})
+}
@@ -0,0 +1,248 @@
+package routing_test
+
+import (
+	"context"
+	"errors"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/BochkovDev/kademlia-go/node"
+	"github.com/BochkovDev/kademlia-go/routing"
+)
+
+// mapKVBackend is an in-memory routing.KVBackend test double, standing in
+// for a real embedded database like BoltDB.
+type mapKVBackend struct {
+	mu      sync.Mutex
+	buckets map[string]map[string][]byte
+}
+
+func newMapKVBackend() *mapKVBackend {
+	return &mapKVBackend{buckets: make(map[string]map[string][]byte)}
+}
+
+func (m *mapKVBackend) Put(bucket, key, value []byte) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	bkt, ok := m.buckets[string(bucket)]
+	if !ok {
+		bkt = make(map[string][]byte)
+		m.buckets[string(bucket)] = bkt
+	}
+	bkt[string(key)] = append([]byte(nil), value...)
+	return nil
+}
+
+func (m *mapKVBackend) Get(bucket, key []byte) ([]byte, bool, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	bkt, ok := m.buckets[string(bucket)]
+	if !ok {
+		return nil, false, nil
+	}
+	value, ok := bkt[string(key)]
+	return value, ok, nil
+}
+
+func (m *mapKVBackend) ForEach(bucket []byte, fn func(key, value []byte) error) error {
+	m.mu.Lock()
+	bkt := m.buckets[string(bucket)]
+	entries := make(map[string][]byte, len(bkt))
+	for k, v := range bkt {
+		entries[k] = v
+	}
+	m.mu.Unlock()
+
+	for k, v := range entries {
+		if err := fn([]byte(k), v); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (m *mapKVBackend) DeleteBucket(bucket []byte) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	delete(m.buckets, string(bucket))
+	return nil
+}
+
+// buildTestBuckets returns a small, deterministic set of KBuckets to
+// exercise a Store with, with nodes spread across a couple of buckets.
+func buildTestBuckets(t *testing.T) []*routing.KBucket {
+	t.Helper()
+
+	local := node.NewNodeID(node.SHA1Hasher{}, []byte("local_node"))
+	rt := routing.NewRoutingTable(local, 2)
+
+	for i := 0; i < 8; i++ {
+		rt.Add(node.NewNode([]byte{byte(i)}, nil, uint16(1000+i)))
+	}
+
+	return rt.Buckets()
+}
+
+// assertBucketsEqual checks that got and want hold the same nodes, in the
+// same order, per bucket.
+func assertBucketsEqual(t *testing.T, want, got []*routing.KBucket) {
+	t.Helper()
+
+	if len(want) != len(got) {
+		t.Fatalf("bucket count mismatch: want %d, got %d", len(want), len(got))
+	}
+
+	for i := range want {
+		wantNodes := want[i].Nodes()
+		gotNodes := got[i].Nodes()
+		if len(wantNodes) != len(gotNodes) {
+			t.Fatalf("bucket %d: node count mismatch: want %d, got %d", i, len(wantNodes), len(gotNodes))
+		}
+		for j := range wantNodes {
+			if !wantNodes[j].ID().Equals(gotNodes[j].ID()) {
+				t.Fatalf("bucket %d, node %d: ID mismatch", i, j)
+			}
+		}
+	}
+}
+
+// TestMemoryStoreRoundTrip checks that MemoryStore's Save/Load round-trips
+// a bucket snapshot through its binary encoding.
+func TestMemoryStoreRoundTrip(t *testing.T) {
+	buckets := buildTestBuckets(t)
+	s := routing.NewMemoryStore()
+
+	if err := s.Save(buckets); err != nil {
+		t.Fatalf("Save() failed: %v", err)
+	}
+
+	got, err := s.Load()
+	if err != nil {
+		t.Fatalf("Load() failed: %v", err)
+	}
+
+	assertBucketsEqual(t, buckets, got)
+}
+
+// TestMemoryStoreLoadBeforeSave checks that Load on a fresh MemoryStore
+// returns a nil snapshot rather than an error.
+func TestMemoryStoreLoadBeforeSave(t *testing.T) {
+	s := routing.NewMemoryStore()
+
+	got, err := s.Load()
+	if err != nil {
+		t.Fatalf("Load() failed: %v", err)
+	}
+	if got != nil {
+		t.Fatalf("Load() on an empty store should return nil, got %d buckets", len(got))
+	}
+}
+
+// TestJSONStoreRoundTrip checks that JSONStore's Save/Load round-trips a
+// bucket snapshot through a JSON file on disk.
+func TestJSONStoreRoundTrip(t *testing.T) {
+	buckets := buildTestBuckets(t)
+	path := filepath.Join(t.TempDir(), "routing-table.json")
+	s := routing.NewJSONStore(path)
+
+	if err := s.Save(buckets); err != nil {
+		t.Fatalf("Save() failed: %v", err)
+	}
+
+	got, err := s.Load()
+	if err != nil {
+		t.Fatalf("Load() failed: %v", err)
+	}
+
+	assertBucketsEqual(t, buckets, got)
+}
+
+// TestJSONStoreLoadMissingFile checks that Load returns a nil snapshot
+// rather than an error when the backing file does not exist yet.
+func TestJSONStoreLoadMissingFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "does-not-exist.json")
+	s := routing.NewJSONStore(path)
+
+	got, err := s.Load()
+	if err != nil {
+		t.Fatalf("Load() failed: %v", err)
+	}
+	if got != nil {
+		t.Fatalf("Load() on a missing file should return nil, got %d buckets", len(got))
+	}
+}
+
+// TestKVStoreRoundTrip checks that KVStore's Save/Load round-trips a bucket
+// snapshot through the kadBucketDB/nodeBucketDB split, backed by an
+// in-memory KVBackend.
+func TestKVStoreRoundTrip(t *testing.T) {
+	buckets := buildTestBuckets(t)
+	s := routing.NewKVStore(newMapKVBackend())
+
+	if err := s.Save(buckets); err != nil {
+		t.Fatalf("Save() failed: %v", err)
+	}
+
+	got, err := s.Load()
+	if err != nil {
+		t.Fatalf("Load() failed: %v", err)
+	}
+
+	assertBucketsEqual(t, buckets, got)
+}
+
+// TestRoutingTableFlushAndLoadFrom checks that RoutingTable.Flush saves the
+// current bucket tree and LoadFrom restores it into a fresh table.
+func TestRoutingTableFlushAndLoadFrom(t *testing.T) {
+	local := node.NewNodeID(node.SHA1Hasher{}, []byte("local_node"))
+	rt := routing.NewRoutingTable(local, 2)
+	for i := 0; i < 8; i++ {
+		rt.Add(node.NewNode([]byte{byte(i)}, nil, uint16(1000+i)))
+	}
+
+	s := routing.NewMemoryStore()
+	if err := rt.Flush(s); err != nil {
+		t.Fatalf("Flush() failed: %v", err)
+	}
+
+	restored := routing.NewRoutingTable(local, 2)
+	if err := restored.LoadFrom(s); err != nil {
+		t.Fatalf("LoadFrom() failed: %v", err)
+	}
+
+	if restored.TotalNodes() != rt.TotalNodes() {
+		t.Fatalf("LoadFrom() node count mismatch: want %d, got %d", rt.TotalNodes(), restored.TotalNodes())
+	}
+	assertBucketsEqual(t, rt.Buckets(), restored.Buckets())
+}
+
+// TestStartFlushingStopsOnContextCancel checks that StartFlushing returns
+// ctx.Err() once ctx is canceled, after performing at least one flush.
+func TestStartFlushingStopsOnContextCancel(t *testing.T) {
+	local := node.NewNodeID(node.SHA1Hasher{}, []byte("local_node"))
+	rt := routing.NewRoutingTable(local, 2)
+	rt.Add(node.NewNode([]byte("peer_1"), nil, 1))
+
+	s := routing.NewMemoryStore()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+
+	err := rt.StartFlushing(ctx, s, time.Millisecond)
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("StartFlushing() error = %v, want context.DeadlineExceeded", err)
+	}
+
+	got, loadErr := s.Load()
+	if loadErr != nil {
+		t.Fatalf("Load() failed: %v", loadErr)
+	}
+	if got == nil {
+		t.Fatal("expected at least one flush before the context was canceled")
+	}
+}
@@ -0,0 +1,360 @@
+package routing
+
+import (
+	"bytes"
+	"context"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/BochkovDev/kademlia-go/node"
+)
+
+// RoutingTable implements the full Kademlia routing tree: a sequence of
+// KBuckets covering increasingly narrow slices of the keyspace around the
+// local node's ID. The keyspace width is whatever the local ID's concrete
+// implementation reports via BitLen (160 bits for node.ID160, 256 for
+// node.ID256), rather than a constant hard-wired into the table.
+//
+// A freshly created RoutingTable holds a single KBucket covering the whole
+// keyspace. As nodes are added, the bucket that would hold the local ID is
+// split in two whenever it becomes full, following the rule from Section
+// 2.2 of the Kademlia paper ("split the bucket associated with the range
+// containing its own node ID"). Buckets that do not cover the local ID are
+// never split; they rely on KBucket's own LRS eviction policy instead.
+//
+// References:
+//   - [Maymounkov, Petar; Mazieres, David. "Kademlia: A Peer-to-peer Information System Based on the XOR Metric"] [Section 2.2, "Node State"]
+//     https://pdos.csail.mit.edu/~petar/papers/maymounkov-kademlia-lncs.pdf
+type RoutingTable struct {
+	// local is the ID of the owner of this routing table. Bucket boundaries
+	// and the split decision are always computed relative to it; its
+	// BitLen and CommonPrefixLen implementation determine the keyspace
+	// width the whole table operates over, so ksize and bit-width are
+	// configured together simply by the local ID passed to NewRoutingTable.
+	local node.ID
+
+	// ksize is the capacity passed to every KBucket created by this table.
+	ksize uint8
+
+	// buckets holds the routing tree in order of increasing common-prefix
+	// length with local. buckets[i] stores nodes whose common prefix length
+	// with local is exactly i, except for the last bucket, which stores
+	// every node whose common prefix length is >= len(buckets)-1 (it has
+	// not been split yet).
+	buckets []*KBucket
+
+	// mu guards buckets, since splitting replaces the slice.
+	mu sync.RWMutex
+}
+
+// NewRoutingTable creates a RoutingTable for the given local ID, starting
+// with a single KBucket of capacity ksize that covers the entire keyspace.
+// The table's keyspace width is whatever local.BitLen() reports, so ksize
+// and bit-width are always configured together through local's concrete
+// type (e.g. node.ID160 for the historical 160-bit keyspace, node.ID256 for
+// a SHA-256 or Keccak-256 one).
+//
+// Parameters:
+//   - local node.ID: The ID of the owner of this routing table.
+//   - ksize uint8: The capacity of every KBucket managed by the table.
+//
+// Returns:
+//   - *RoutingTable: A pointer to a newly created RoutingTable.
+func NewRoutingTable(local node.ID, ksize uint8) *RoutingTable {
+	return &RoutingTable{
+		local:   local,
+		ksize:   ksize,
+		buckets: []*KBucket{NewKBucket(ksize)},
+	}
+}
+
+// bucketIndex returns the index into rt.buckets that should hold id,
+// according to its common-prefix length with the local ID. Callers must
+// hold rt.mu.
+func (rt *RoutingTable) bucketIndex(id node.ID) int {
+	cpl := rt.local.CommonPrefixLen(id)
+	if cpl >= len(rt.buckets) {
+		return len(rt.buckets) - 1
+	}
+	return cpl
+}
+
+// Add inserts n into the appropriate KBucket, splitting the bucket covering
+// the local ID's range when it is full.
+//
+// If the bucket n belongs to is not full (or already contains n), the node
+// is added directly. If the bucket is full and covers the local ID's range,
+// it is split into two half-range buckets and the insertion is retried. If
+// the bucket is full and does not cover the local ID's range, KBucket's own
+// least-recently-seen eviction runs instead.
+//
+// Parameters:
+//   - n node.INode: The node to insert into the routing table.
+func (rt *RoutingTable) Add(n node.INode) {
+	rt.mu.Lock()
+	defer rt.mu.Unlock()
+
+	for {
+		idx := rt.bucketIndex(n.ID())
+		bucket := rt.buckets[idx]
+
+		if !bucket.IsFull() || bucket.Contains(n.ID()) {
+			bucket.Add(n)
+			return
+		}
+
+		if idx != len(rt.buckets)-1 {
+			// Not the bucket containing the local range: fall back to the
+			// standard LRS eviction path.
+			bucket.Add(n)
+			return
+		}
+
+		rt.split(idx)
+	}
+}
+
+// split divides the bucket at idx (the last bucket, which covers every
+// common-prefix length >= idx) into two buckets: one holding nodes whose
+// common-prefix length with local is exactly idx, and a new last bucket
+// holding everything deeper. Callers must hold rt.mu.
+func (rt *RoutingTable) split(idx int) {
+	old := rt.buckets[idx]
+	near := NewKBucket(rt.ksize)
+	far := NewKBucket(rt.ksize)
+
+	for _, n := range old.Nodes() {
+		if rt.local.CommonPrefixLen(n.ID()) == idx {
+			near.Add(n)
+		} else {
+			far.Add(n)
+		}
+	}
+
+	rt.buckets[idx] = near
+	rt.buckets = append(rt.buckets, far)
+}
+
+// Closest walks the routing table's buckets and returns up to count nodes
+// sorted by XOR distance to target, closest first.
+//
+// Parameters:
+//   - target node.ID: The ID to measure distance against.
+//   - count int: The maximum number of nodes to return.
+//
+// Returns:
+//   - []node.INode: Up to count nodes, ordered by ascending XOR distance to target.
+func (rt *RoutingTable) Closest(target node.ID, count int) []node.INode {
+	rt.mu.RLock()
+	defer rt.mu.RUnlock()
+
+	all := make([]node.INode, 0, rt.totalNodesLocked())
+	for _, bucket := range rt.buckets {
+		all = append(all, bucket.Nodes()...)
+	}
+
+	sort.Slice(all, func(i, j int) bool {
+		di := target.XOR(all[i].ID())
+		dj := target.XOR(all[j].ID())
+		return bytes.Compare(di.Bytes(), dj.Bytes()) < 0
+	})
+
+	if count > len(all) {
+		count = len(all)
+	}
+	return all[:count]
+}
+
+// FindClosest is Closest filtered down to the table's own *node.Node
+// entries, for callers that want the concrete type rather than the INode
+// interface. Entries that are not *node.Node (a custom INode implementation
+// added directly to a bucket) are silently skipped.
+//
+// Parameters:
+//   - target node.ID: The ID to measure distance against.
+//   - k int: The maximum number of nodes to return.
+//
+// Returns:
+//   - []*node.Node: Up to k nodes, ordered by ascending XOR distance to target.
+func (rt *RoutingTable) FindClosest(target node.ID, k int) []*node.Node {
+	closest := rt.Closest(target, k)
+
+	out := make([]*node.Node, 0, len(closest))
+	for _, n := range closest {
+		if concrete, ok := n.(*node.Node); ok {
+			out = append(out, concrete)
+		}
+	}
+	return out
+}
+
+// BucketForID returns the KBucket that would hold id, based on its
+// common-prefix length with the local ID.
+//
+// Parameters:
+//   - id node.ID: The ID to locate a bucket for.
+//
+// Returns:
+//   - *KBucket: The bucket covering id's range.
+func (rt *RoutingTable) BucketForID(id node.ID) *KBucket {
+	rt.mu.RLock()
+	defer rt.mu.RUnlock()
+
+	return rt.buckets[rt.bucketIndex(id)]
+}
+
+// Buckets returns a snapshot of every KBucket in the routing tree, in order
+// of increasing common-prefix length with the local ID.
+//
+// Returns:
+//   - []*KBucket: The routing tree's current buckets.
+func (rt *RoutingTable) Buckets() []*KBucket {
+	rt.mu.RLock()
+	defer rt.mu.RUnlock()
+
+	buckets := make([]*KBucket, len(rt.buckets))
+	copy(buckets, rt.buckets)
+	return buckets
+}
+
+// BucketCount returns the number of KBuckets currently in the routing tree.
+//
+// Returns:
+//   - int: The number of buckets.
+func (rt *RoutingTable) BucketCount() int {
+	rt.mu.RLock()
+	defer rt.mu.RUnlock()
+
+	return len(rt.buckets)
+}
+
+// TotalNodes returns the total number of nodes stored across every bucket in
+// the routing table.
+//
+// Returns:
+//   - int: The total number of nodes.
+func (rt *RoutingTable) TotalNodes() int {
+	rt.mu.RLock()
+	defer rt.mu.RUnlock()
+
+	return rt.totalNodesLocked()
+}
+
+// totalNodesLocked is the unsynchronized implementation of TotalNodes.
+// Callers must hold rt.mu.
+func (rt *RoutingTable) totalNodesLocked() int {
+	total := 0
+	for _, bucket := range rt.buckets {
+		total += int(bucket.Size())
+	}
+	return total
+}
+
+// Local returns the ID this routing table is organized around.
+//
+// Returns:
+//   - node.ID: The local ID.
+func (rt *RoutingTable) Local() node.ID {
+	return rt.local
+}
+
+// KSize returns the capacity passed to every KBucket managed by this table.
+//
+// Returns:
+//   - uint8: The per-bucket capacity.
+func (rt *RoutingTable) KSize() uint8 {
+	return rt.ksize
+}
+
+// Flush saves a snapshot of every bucket in the routing tree to s, so a
+// restarting node can warm its routing table through s.Load instead of
+// relying solely on a fresh bootstrap.
+//
+// Parameters:
+//   - s Store: The backend the snapshot is saved to.
+//
+// Returns:
+//   - error: Non-nil if s.Save failed.
+func (rt *RoutingTable) Flush(s Store) error {
+	return s.Save(rt.Buckets())
+}
+
+// LoadFrom re-inserts, through Add, every node saved to s, if any. It is
+// meant to be called once, right after NewRoutingTable, before the table
+// starts serving lookups.
+//
+// Nodes are re-inserted through Add rather than adopting the saved buckets
+// wholesale, the same way persistence.Restore rebuilds a RoutingTable: a
+// bucket slot is only meaningful as "nodes at common-prefix-length i with
+// this table's local ID", and a snapshot saved under a different local ID
+// (or a different keyspace width) would make rt.buckets[i] mean something
+// else entirely if swapped in directly. Re-inserting rebuilds the bucket
+// tree correctly for whatever local ID and ksize this table already has,
+// regardless of what they were when the snapshot was taken.
+//
+// Parameters:
+//   - s Store: The backend the snapshot is loaded from.
+//
+// Returns:
+//   - error: Non-nil if s.Load failed.
+func (rt *RoutingTable) LoadFrom(s Store) error {
+	buckets, err := s.Load()
+	if err != nil {
+		return err
+	}
+
+	for _, bucket := range buckets {
+		for _, n := range bucket.Nodes() {
+			rt.Add(n)
+		}
+	}
+	return nil
+}
+
+// StartFlushing calls Flush against s every interval, blocking the calling
+// goroutine until ctx is done or a Flush fails. It is the periodic
+// counterpart to calling Flush on demand.
+//
+// Parameters:
+//   - ctx context.Context: Governs the loop's lifetime.
+//   - s Store: The backend each periodic snapshot is saved to.
+//   - interval time.Duration: How often the routing table is flushed.
+//
+// Returns:
+//   - error: ctx.Err() once ctx is done, or the first Flush error encountered.
+func (rt *RoutingTable) StartFlushing(ctx context.Context, s Store, interval time.Duration) error {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-ticker.C:
+			if err := rt.Flush(s); err != nil {
+				return err
+			}
+		}
+	}
+}
+
+// ForEachBucket iterates over every KBucket in the routing tree in order of
+// increasing common-prefix length, invoking fn with the bucket's index and
+// the bucket itself. Iteration stops early if fn returns false.
+//
+// Parameters:
+//   - fn func(prefixLen int, bucket *KBucket) bool: Called for every bucket;
+//     returning false stops the iteration.
+func (rt *RoutingTable) ForEachBucket(fn func(prefixLen int, bucket *KBucket) bool) {
+	rt.mu.RLock()
+	buckets := make([]*KBucket, len(rt.buckets))
+	copy(buckets, rt.buckets)
+	rt.mu.RUnlock()
+
+	for i, bucket := range buckets {
+		if !fn(i, bucket) {
+			return
+		}
+	}
+}
@@ -0,0 +1,140 @@
+package routing_test
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/BochkovDev/kademlia-go/node"
+	"github.com/BochkovDev/kademlia-go/routing"
+
+	"github.com/stretchr/testify/suite"
+)
+
+// RoutingTableTestSuite defines the test structure for RoutingTable tests.
+type RoutingTableTestSuite struct {
+	suite.Suite
+	local node.ID
+	rt    *routing.RoutingTable
+}
+
+// SetupTest initializes a fresh RoutingTable before each test.
+func (suite *RoutingTableTestSuite) SetupTest() {
+	suite.local = node.NewNodeID(node.SHA1Hasher{}, []byte("local_node"))
+	suite.rt = routing.NewRoutingTable(suite.local, 2)
+}
+
+// TestNewRoutingTableStartsWithOneBucket checks that a new table starts with
+// a single bucket covering the whole keyspace.
+func (suite *RoutingTableTestSuite) TestNewRoutingTableStartsWithOneBucket() {
+	suite.Equal(1, suite.rt.BucketCount())
+	suite.Equal(0, suite.rt.TotalNodes())
+}
+
+// TestAddWithoutSplit checks that nodes are added without splitting while
+// buckets still have room.
+func (suite *RoutingTableTestSuite) TestAddWithoutSplit() {
+	n1 := node.NewNode([]byte("peer_1"), nil, 1)
+	suite.rt.Add(n1)
+
+	suite.Equal(1, suite.rt.BucketCount())
+	suite.Equal(1, suite.rt.TotalNodes())
+}
+
+// TestAddSplitsBucketContainingLocal checks that filling the bucket covering
+// the local ID's range causes a split instead of evicting a node.
+func (suite *RoutingTableTestSuite) TestAddSplitsBucketContainingLocal() {
+	for i := 0; i < 8; i++ {
+		suite.rt.Add(node.NewNode([]byte{byte(i)}, nil, 1))
+	}
+
+	if suite.rt.BucketCount() <= 1 {
+		suite.Fail("expected routing table to split into multiple buckets")
+	}
+	suite.Equal(8, suite.rt.TotalNodes())
+}
+
+// TestClosestOrdersByXORDistance checks that Closest returns nodes ordered by
+// ascending XOR distance to the target.
+func (suite *RoutingTableTestSuite) TestClosestOrdersByXORDistance() {
+	nodes := make([]*node.Node, 0, 5)
+	for i := 0; i < 5; i++ {
+		n := node.NewNode([]byte{byte(i)}, nil, 1)
+		nodes = append(nodes, n)
+		suite.rt.Add(n)
+	}
+
+	target := nodes[0].ID()
+	closest := suite.rt.Closest(target, 3)
+
+	suite.Len(closest, 3)
+	for i := 1; i < len(closest); i++ {
+		prev := target.XOR(closest[i-1].ID())
+		cur := target.XOR(closest[i].ID())
+		suite.True(lessOrEqual(prev, cur), "Closest should return nodes sorted by ascending XOR distance")
+	}
+}
+
+// TestFindClosestReturnsConcreteNodes checks that FindClosest returns the
+// same ordering as Closest, narrowed to the *node.Node concrete type.
+func (suite *RoutingTableTestSuite) TestFindClosestReturnsConcreteNodes() {
+	nodes := make([]*node.Node, 0, 5)
+	for i := 0; i < 5; i++ {
+		n := node.NewNode([]byte{byte(i)}, nil, 1)
+		nodes = append(nodes, n)
+		suite.rt.Add(n)
+	}
+
+	target := nodes[0].ID()
+	closest := suite.rt.FindClosest(target, 3)
+
+	suite.Len(closest, 3)
+	for i := 1; i < len(closest); i++ {
+		prev := target.XOR(closest[i-1].ID())
+		cur := target.XOR(closest[i].ID())
+		suite.True(lessOrEqual(prev, cur), "FindClosest should return nodes sorted by ascending XOR distance")
+	}
+}
+
+// TestBucketForID checks that BucketForID returns the same bucket Add placed
+// a node into.
+func (suite *RoutingTableTestSuite) TestBucketForID() {
+	n := node.NewNode([]byte("peer_1"), nil, 1)
+	suite.rt.Add(n)
+
+	bucket := suite.rt.BucketForID(n.ID())
+	suite.True(bucket.Contains(n.ID()))
+}
+
+// TestBuckets checks that Buckets returns a snapshot matching BucketCount.
+func (suite *RoutingTableTestSuite) TestBuckets() {
+	for i := 0; i < 8; i++ {
+		suite.rt.Add(node.NewNode([]byte{byte(i)}, nil, 1))
+	}
+
+	suite.Len(suite.rt.Buckets(), suite.rt.BucketCount())
+}
+
+// TestForEachBucket checks that the iterator visits every bucket.
+func (suite *RoutingTableTestSuite) TestForEachBucket() {
+	for i := 0; i < 8; i++ {
+		suite.rt.Add(node.NewNode([]byte{byte(i)}, nil, 1))
+	}
+
+	visited := 0
+	suite.rt.ForEachBucket(func(prefixLen int, bucket *routing.KBucket) bool {
+		visited++
+		return true
+	})
+
+	suite.Equal(suite.rt.BucketCount(), visited)
+}
+
+// lessOrEqual reports whether a <= b when compared as big-endian byte strings.
+func lessOrEqual(a, b node.ID) bool {
+	return bytes.Compare(a.Bytes(), b.Bytes()) <= 0
+}
+
+// TestRoutingTableTestSuite runs the test suite.
+func TestRoutingTableTestSuite(t *testing.T) {
+	suite.Run(t, new(RoutingTableTestSuite))
+}
@@ -0,0 +1,28 @@
+package store
+
+import "github.com/BochkovDev/kademlia-go/node"
+
+// Store defines the interface for holding the key/value Records a Kademlia
+// node is responsible for.
+//
+// Methods:
+//
+//   - Put(record Record) error:
+//     Inserts or replaces the record for record.Key. Implementations may
+//     reject the record, for example when a per-publisher quota is exceeded.
+//
+//   - Get(key node.ID) (Record, bool):
+//     Returns the record stored under key, if any.
+//
+//   - Delete(key node.ID):
+//     Removes the record stored under key, if any.
+//
+//   - Iterate(fn func(Record) bool):
+//     Calls fn once for every record currently held, stopping early if fn
+//     returns false.
+type Store interface {
+	Put(record Record) error
+	Get(key node.ID) (Record, bool)
+	Delete(key node.ID)
+	Iterate(fn func(Record) bool)
+}
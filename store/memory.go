@@ -0,0 +1,253 @@
+package store
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+
+	"github.com/BochkovDev/kademlia-go/node"
+)
+
+// DefaultRepublishInterval is the default cadence at which a record becomes
+// due for re-publication, matching the tRefresh constant from the original
+// Kademlia paper.
+const DefaultRepublishInterval = time.Hour
+
+// DefaultExpireInterval is the default interval at which the janitor sweeps
+// the store for expired records.
+const DefaultExpireInterval = time.Minute
+
+// ErrPublisherQuotaExceeded is returned by Put when accepting a new record
+// would push its publisher over the store's per-publisher record cap.
+var ErrPublisherQuotaExceeded = errors.New("store: publisher record quota exceeded")
+
+// entry wraps a Record with the bookkeeping the janitor needs to decide when
+// it is next due for re-publication.
+type entry struct {
+	record        Record
+	nextRepublish time.Time
+}
+
+// MemoryStore is an in-memory Store implementation guarded by a
+// sync.RWMutex. A background janitor goroutine, started with Start, expires
+// records whose TTL has elapsed and emits records due for re-publication on
+// the Republish channel so a future RPC layer can re-broadcast them.
+type MemoryStore struct {
+	mu sync.RWMutex
+
+	// entries holds every record currently accepted by the store, keyed by
+	// its NodeID key.
+	entries map[node.ID]*entry
+
+	// publisherCounts tracks how many records are currently attributed to
+	// each publisher, used to enforce maxPerPublisher.
+	publisherCounts map[node.ID]int
+
+	// maxPerPublisher bounds how many records a single publisher may have
+	// accepted at once. Zero means unlimited.
+	maxPerPublisher int
+
+	// expireInterval is how often the janitor sweeps for expired records.
+	expireInterval time.Duration
+
+	// republishInterval is how often an individual record becomes due for
+	// re-publication.
+	republishInterval time.Duration
+
+	// republish is the channel records due for re-publication are sent on.
+	republish chan Record
+}
+
+// NewMemoryStore creates an in-memory Store.
+//
+// Parameters:
+//   - maxPerPublisher int: The maximum number of records a single publisher
+//     may have accepted at once; zero disables the cap.
+//   - expireInterval time.Duration: How often the janitor sweeps for expired
+//     records; zero uses DefaultExpireInterval.
+//   - republishInterval time.Duration: How often a record becomes due for
+//     re-publication; zero uses DefaultRepublishInterval.
+//
+// Returns:
+//   - *MemoryStore: A pointer to a newly created, empty MemoryStore.
+func NewMemoryStore(maxPerPublisher int, expireInterval, republishInterval time.Duration) *MemoryStore {
+	if expireInterval <= 0 {
+		expireInterval = DefaultExpireInterval
+	}
+	if republishInterval <= 0 {
+		republishInterval = DefaultRepublishInterval
+	}
+
+	return &MemoryStore{
+		entries:           make(map[node.ID]*entry),
+		publisherCounts:   make(map[node.ID]int),
+		maxPerPublisher:   maxPerPublisher,
+		expireInterval:    expireInterval,
+		republishInterval: republishInterval,
+		republish:         make(chan Record, 64),
+	}
+}
+
+// Put inserts or replaces the record for record.Key.
+//
+// If record.Key is not yet present, the insertion is rejected with
+// ErrPublisherQuotaExceeded when record.Publisher already holds
+// maxPerPublisher records. Replacing an existing record transfers the
+// publisher-quota accounting from the old publisher to the new one.
+//
+// Parameters:
+//   - record Record: The record to store.
+//
+// Returns:
+//   - error: ErrPublisherQuotaExceeded if the publisher's quota would be
+//     exceeded, nil otherwise.
+func (ms *MemoryStore) Put(record Record) error {
+	ms.mu.Lock()
+	defer ms.mu.Unlock()
+
+	existing, exists := ms.entries[record.Key]
+
+	if !exists || existing.record.Publisher != record.Publisher {
+		if ms.maxPerPublisher > 0 && ms.publisherCounts[record.Publisher] >= ms.maxPerPublisher {
+			return ErrPublisherQuotaExceeded
+		}
+		if exists {
+			ms.publisherCounts[existing.record.Publisher]--
+		}
+		ms.publisherCounts[record.Publisher]++
+	}
+
+	ms.entries[record.Key] = &entry{
+		record:        record,
+		nextRepublish: record.Received.Add(ms.republishInterval),
+	}
+	return nil
+}
+
+// Get returns the record stored under key, if any.
+//
+// Parameters:
+//   - key node.ID: The key to look up.
+//
+// Returns:
+//   - Record: The stored record, or the zero value if not found.
+//   - bool: True if a record was found under key.
+func (ms *MemoryStore) Get(key node.ID) (Record, bool) {
+	ms.mu.RLock()
+	defer ms.mu.RUnlock()
+
+	e, ok := ms.entries[key]
+	if !ok {
+		return Record{}, false
+	}
+	return e.record, true
+}
+
+// Delete removes the record stored under key, if any.
+//
+// Parameters:
+//   - key node.ID: The key of the record to remove.
+func (ms *MemoryStore) Delete(key node.ID) {
+	ms.mu.Lock()
+	defer ms.mu.Unlock()
+
+	ms.deleteLocked(key)
+}
+
+// deleteLocked removes the record stored under key and reconciles the
+// per-publisher count. Callers must hold ms.mu.
+func (ms *MemoryStore) deleteLocked(key node.ID) {
+	e, ok := ms.entries[key]
+	if !ok {
+		return
+	}
+	delete(ms.entries, key)
+	ms.publisherCounts[e.record.Publisher]--
+	if ms.publisherCounts[e.record.Publisher] <= 0 {
+		delete(ms.publisherCounts, e.record.Publisher)
+	}
+}
+
+// Iterate calls fn once for every record currently held, stopping early if
+// fn returns false.
+//
+// Parameters:
+//   - fn func(Record) bool: Called for every record; returning false stops
+//     the iteration.
+func (ms *MemoryStore) Iterate(fn func(Record) bool) {
+	ms.mu.RLock()
+	records := make([]Record, 0, len(ms.entries))
+	for _, e := range ms.entries {
+		records = append(records, e.record)
+	}
+	ms.mu.RUnlock()
+
+	for _, r := range records {
+		if !fn(r) {
+			return
+		}
+	}
+}
+
+// Republish returns the channel records due for re-publication are sent on.
+// A future RPC layer is expected to drain this channel and re-broadcast
+// STORE messages for whatever it receives.
+//
+// Returns:
+//   - <-chan Record: The receive-only republish channel.
+func (ms *MemoryStore) Republish() <-chan Record {
+	return ms.republish
+}
+
+// Start launches the janitor goroutine, which periodically drops expired
+// records and emits records due for re-publication on the Republish channel.
+// The goroutine runs until ctx is done.
+//
+// Parameters:
+//   - ctx context.Context: Governs the goroutine's lifetime.
+func (ms *MemoryStore) Start(ctx context.Context) {
+	go ms.run(ctx)
+}
+
+// run is the janitor loop launched by Start.
+func (ms *MemoryStore) run(ctx context.Context) {
+	ticker := time.NewTicker(ms.expireInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case now := <-ticker.C:
+			ms.sweep(now)
+		}
+	}
+}
+
+// sweep drops expired records and emits due records on the republish
+// channel. Emission is non-blocking: a full Republish channel causes that
+// record's re-publication to be skipped rather than stalling the janitor,
+// and the record is next due one republish interval later.
+func (ms *MemoryStore) sweep(now time.Time) {
+	ms.mu.Lock()
+	var due []Record
+	for key, e := range ms.entries {
+		if e.record.Expired(now) {
+			ms.deleteLocked(key)
+			continue
+		}
+		if !now.Before(e.nextRepublish) {
+			due = append(due, e.record)
+			e.nextRepublish = now.Add(ms.republishInterval)
+		}
+	}
+	ms.mu.Unlock()
+
+	for _, r := range due {
+		select {
+		case ms.republish <- r:
+		default:
+		}
+	}
+}
@@ -0,0 +1,126 @@
+package store_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/BochkovDev/kademlia-go/node"
+	"github.com/BochkovDev/kademlia-go/store"
+)
+
+// TestPutAndGet checks that a record can be stored and retrieved.
+func TestPutAndGet(t *testing.T) {
+	ms := store.NewMemoryStore(0, time.Minute, time.Hour)
+	key := node.NewNodeID(node.SHA1Hasher{}, []byte("key_1"))
+	publisher := node.NewNodeID(node.SHA1Hasher{}, []byte("publisher_1"))
+
+	record := store.Record{Key: key, Value: []byte("value_1"), Publisher: publisher, Received: time.Now(), TTL: time.Hour}
+	if err := ms.Put(record); err != nil {
+		t.Fatalf("Put failed: %v", err)
+	}
+
+	got, ok := ms.Get(key)
+	if !ok {
+		t.Fatal("Get failed, expected record to be found")
+	}
+	if string(got.Value) != "value_1" {
+		t.Errorf("Get failed, expected value %q, got %q", "value_1", got.Value)
+	}
+}
+
+// TestDelete checks that a deleted record is no longer retrievable.
+func TestDelete(t *testing.T) {
+	ms := store.NewMemoryStore(0, time.Minute, time.Hour)
+	key := node.NewNodeID(node.SHA1Hasher{}, []byte("key_1"))
+	publisher := node.NewNodeID(node.SHA1Hasher{}, []byte("publisher_1"))
+
+	ms.Put(store.Record{Key: key, Publisher: publisher, Received: time.Now(), TTL: time.Hour})
+	ms.Delete(key)
+
+	if _, ok := ms.Get(key); ok {
+		t.Error("Get failed, expected record to be removed after Delete")
+	}
+}
+
+// TestPublisherQuota checks that a publisher cannot exceed its record cap.
+func TestPublisherQuota(t *testing.T) {
+	ms := store.NewMemoryStore(1, time.Minute, time.Hour)
+	publisher := node.NewNodeID(node.SHA1Hasher{}, []byte("publisher_1"))
+
+	first := store.Record{Key: node.NewNodeID(node.SHA1Hasher{}, []byte("key_1")), Publisher: publisher, Received: time.Now(), TTL: time.Hour}
+	second := store.Record{Key: node.NewNodeID(node.SHA1Hasher{}, []byte("key_2")), Publisher: publisher, Received: time.Now(), TTL: time.Hour}
+
+	if err := ms.Put(first); err != nil {
+		t.Fatalf("Put failed for first record: %v", err)
+	}
+	if err := ms.Put(second); err != store.ErrPublisherQuotaExceeded {
+		t.Fatalf("Put failed, expected ErrPublisherQuotaExceeded, got %v", err)
+	}
+}
+
+// TestJanitorExpiresRecords checks that the janitor drops records whose TTL
+// has elapsed.
+func TestJanitorExpiresRecords(t *testing.T) {
+	ms := store.NewMemoryStore(0, 10*time.Millisecond, time.Hour)
+	key := node.NewNodeID(node.SHA1Hasher{}, []byte("key_1"))
+	publisher := node.NewNodeID(node.SHA1Hasher{}, []byte("publisher_1"))
+
+	ms.Put(store.Record{Key: key, Publisher: publisher, Received: time.Now(), TTL: 5 * time.Millisecond})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	ms.Start(ctx)
+
+	deadline := time.Now().Add(time.Second)
+	for time.Now().Before(deadline) {
+		if _, ok := ms.Get(key); !ok {
+			return
+		}
+		time.Sleep(5 * time.Millisecond)
+	}
+	t.Error("expected janitor to expire the record within the deadline")
+}
+
+// TestJanitorEmitsRepublish checks that the janitor emits due records on the
+// Republish channel.
+func TestJanitorEmitsRepublish(t *testing.T) {
+	ms := store.NewMemoryStore(0, 10*time.Millisecond, 5*time.Millisecond)
+	key := node.NewNodeID(node.SHA1Hasher{}, []byte("key_1"))
+	publisher := node.NewNodeID(node.SHA1Hasher{}, []byte("publisher_1"))
+
+	ms.Put(store.Record{Key: key, Publisher: publisher, Received: time.Now(), TTL: time.Hour})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	ms.Start(ctx)
+
+	select {
+	case r := <-ms.Republish():
+		if !r.Key.Equals(key) {
+			t.Errorf("Republish failed, expected key %s, got %s", key, r.Key)
+		}
+	case <-time.After(time.Second):
+		t.Error("expected a record to be emitted on the Republish channel")
+	}
+}
+
+// TestIterate checks that Iterate visits every stored record.
+func TestIterate(t *testing.T) {
+	ms := store.NewMemoryStore(0, time.Minute, time.Hour)
+	publisher := node.NewNodeID(node.SHA1Hasher{}, []byte("publisher_1"))
+
+	for i := 0; i < 3; i++ {
+		ms.Put(store.Record{Key: node.NewNodeID(node.SHA1Hasher{}, []byte{byte(i)}), Publisher: publisher, Received: time.Now(), TTL: time.Hour})
+	}
+
+	count := 0
+	ms.Iterate(func(store.Record) bool {
+		count++
+		return true
+	})
+
+	if count != 3 {
+		t.Errorf("Iterate failed, expected 3 records, got %d", count)
+	}
+}
@@ -0,0 +1,52 @@
+package store
+
+import (
+	"time"
+
+	"github.com/BochkovDev/kademlia-go/node"
+)
+
+// Record represents a single key/value entry held by a Kademlia node on
+// behalf of the network, modeled on the publisher and remaining-TTL fields
+// carried by libp2p DHT STORE records.
+//
+// Fields:
+//
+//   - Key node.ID:
+//     The key the value is stored under. Kademlia keys and NodeIDs share the
+//     same keyspace, so lookups for a key use the same XOR-distance routing
+//     as lookups for a node.
+//
+//   - Value []byte:
+//     The opaque data associated with Key.
+//
+//   - Publisher node.ID:
+//     The NodeID of the node that originally published this record. Kept
+//     alongside the value so a republishing node can be identified and so
+//     per-publisher quotas can be enforced.
+//
+//   - Received time.Time:
+//     The time this node accepted the record, used as the base for TTL
+//     expiry and republish scheduling.
+//
+//   - TTL time.Duration:
+//     How long the record remains valid after Received before it is
+//     considered expired and eligible for removal.
+type Record struct {
+	Key       node.ID
+	Value     []byte
+	Publisher node.ID
+	Received  time.Time
+	TTL       time.Duration
+}
+
+// Expired reports whether the record's TTL has elapsed as of now.
+//
+// Parameters:
+//   - now time.Time: The time to check expiry against.
+//
+// Returns:
+//   - bool: True if Received+TTL is at or before now.
+func (r Record) Expired(now time.Time) bool {
+	return !now.Before(r.Received.Add(r.TTL))
+}